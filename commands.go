@@ -0,0 +1,1460 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"visualcron/cron"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// timeLayout is the layout used when printing run times
+const timeLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runTable parses the expression, or every job in a crontab, and
+// prints it as a table, a calendar of the days it fires or a heatmap
+// of when it fires within a day
+//
+// visualcron [-dialect auto] [-output table] "<expression>"
+// visualcron [-dialect auto] [-output table] -f <crontab>
+// visualcron -view calendar [-month 2006-01 | -year 2006] "<expression>"
+// visualcron -view heatmap [-bucket 5] [-colour] "<expression>"...
+func runTable(args []string) int {
+	fs := newFlagSet("visualcron")
+	file := fs.String("f", "", "crontab file to print, or - for stdin")
+	output := fs.String("output", "table", "output format (table, json, yaml)")
+	viewName := fs.String("view", "table", "how table output shows a schedule (table, calendar, heatmap)")
+	month := fs.String("month", "", "month the calendar view shows, as 2006-01 (default this month)")
+	year := fs.Int("year", 0, "year the calendar view shows, instead of a month")
+	bucket := fs.Int("bucket", 1, "minutes in each cell of the heatmap view, which must divide an hour")
+	colour := fs.Bool("colour", false, "colour the heatmap view with ANSI colours")
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	format, err := ParseFormat(*output)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	view, err := ParseView(*viewName)
+	if err == nil && view != ViewTable && format != FormatTable {
+		err = fmt.Errorf("view - %s only works with table output", view)
+	}
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	if view == ViewHeatmap {
+		return printHeatmap(fs, *file, opts, cron.HeatmapOptions{Bucket: *bucket, Colour: *colour})
+	}
+
+	show, err := viewFunc(view, *month, *year)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	if *file != "" {
+		return printCrontab(*file, opts, format, show)
+	}
+
+	// Parse the expression
+	c, ok := parseExpressionArg(fs, opts)
+	if !ok {
+		return 1
+	}
+
+	// Print as table. JSON and YAML include the warnings
+	if format == FormatTable {
+		printWarnings(c.Warnings, "")
+		log.Print(show(c))
+		return 0
+	}
+
+	return printMarshal(c, format)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printCrontab prints every job in a crontab as a table, or as show
+// returns it, followed by the lines that could not be parsed
+//
+// JSON and YAML include the lines that could not be parsed in the
+// output
+func printCrontab(path string, opts cron.ParseOptions, format Format, show func(c *cron.Cron) string) int {
+	crontab, err := readCrontab(path, opts)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	if format != FormatTable {
+		if code := printMarshal(crontab, format); code != 0 || len(crontab.Errors) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	if len(crontab.Entries) == 0 && len(crontab.Errors) == 0 {
+		log.Print("no jobs found")
+		return 0
+	}
+
+	if len(crontab.Entries) > 0 {
+		tables := make([]string, len(crontab.Entries))
+		for i, entry := range crontab.Entries {
+			tables[i] = entry.Header() + show(entry.Cron)
+		}
+		log.Print(strings.Join(tables, "\n"))
+	}
+
+	for _, entry := range crontab.Entries {
+		printWarnings(entry.Cron.Warnings, fmt.Sprintf("line %d - ", entry.Line))
+	}
+
+	for _, err := range crontab.Errors {
+		printError(err)
+	}
+
+	if len(crontab.Errors) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printHeatmap prints one heatmap of every expression left after the
+// flags, or of every job in the crontab at path, followed by the lines
+// that could not be parsed
+func printHeatmap(fs *flag.FlagSet, path string, opts cron.ParseOptions, heatmapOpts cron.HeatmapOptions) int {
+	entries, errs, ok := readEntries(fs, path, opts)
+	if !ok {
+		return 1
+	}
+
+	crons := make([]*cron.Cron, len(entries))
+	for i, entry := range entries {
+		crons[i] = entry.Cron
+	}
+
+	out, err := cron.Heatmap(crons, heatmapOpts)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+	log.Print(out)
+
+	return printErrors(errs)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// viewFunc returns the func that shows a schedule in the view. The
+// calendar shows the month, as 2006-01, or the whole year when it is
+// set, and this month otherwise
+func viewFunc(view View, month string, year int) (func(c *cron.Cron) string, error) {
+	if view == ViewTable {
+		return func(c *cron.Cron) string {
+			return c.Table()
+		}, nil
+	}
+
+	if month != "" && year != 0 {
+		return nil, fmt.Errorf("calendar - use one of -month or -year")
+	}
+
+	if year != 0 {
+		return func(c *cron.Cron) string {
+			return c.CalendarYear(year)
+		}, nil
+	}
+
+	t := time.Now()
+	if month != "" {
+		var err error
+		if t, err = time.Parse("2006-01", month); err != nil {
+			return nil, fmt.Errorf("calendar - invalid month - %s", month)
+		}
+	}
+
+	return func(c *cron.Cron) string {
+		return c.Calendar(t.Year(), t.Month())
+	}, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runNext prints the next n run times of the expression
+//
+// visualcron next [-n 5] [-from <time>] [-tz <zone>] "<expression>"
+func runNext(args []string) int {
+	fs := newFlagSet("next")
+	n := fs.Int("n", 5, "number of run times to print")
+	from := fs.String("from", "", "time to start from (default now)")
+	tz := tzFlag(fs)
+
+	c, ok := parseCommand(fs, args)
+	if !ok {
+		return 1
+	}
+
+	if *n <= 0 {
+		log.Print("error - -n must be more than 0")
+		return 1
+	}
+
+	view, ok := parseViewZone(*tz)
+	if !ok {
+		return 1
+	}
+
+	start, err := ParseTimeIn(*from, time.Now(), view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	printOccurrences(c, c.NextOccurrences(start, *n), view)
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runPrev prints the last n run times of the expression, most
+// recent first
+//
+// visualcron prev [-n 1] [-from <time>] [-tz <zone>] "<expression>"
+func runPrev(args []string) int {
+	fs := newFlagSet("prev")
+	n := fs.Int("n", 1, "number of run times to print")
+	from := fs.String("from", "", "time to search back from (default now)")
+	tz := tzFlag(fs)
+
+	c, ok := parseCommand(fs, args)
+	if !ok {
+		return 1
+	}
+
+	if *n <= 0 {
+		log.Print("error - -n must be more than 0")
+		return 1
+	}
+
+	view, ok := parseViewZone(*tz)
+	if !ok {
+		return 1
+	}
+
+	t, err := ParseTimeIn(*from, time.Now(), view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	printOccurrences(c, c.PrevOccurrences(t, *n), view)
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runBetween prints every run time of the expression between two
+// times
+//
+// visualcron between -from <time> -to <time> [-tz <zone>] "<expression>"
+func runBetween(args []string) int {
+	fs := newFlagSet("between")
+	from := fs.String("from", "", "start of the window (inclusive)")
+	to := fs.String("to", "", "end of the window (inclusive)")
+	tz := tzFlag(fs)
+
+	c, ok := parseCommand(fs, args)
+	if !ok {
+		return 1
+	}
+
+	// Both ends of the window are required
+	if *from == "" || *to == "" {
+		log.Print("error - -from and -to are required")
+		return 1
+	}
+
+	view, ok := parseViewZone(*tz)
+	if !ok {
+		return 1
+	}
+
+	start, err := ParseTimeIn(*from, time.Time{}, view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	end, err := ParseTimeIn(*to, time.Time{}, view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	printOccurrences(c, c.OccurrencesBetween(start, end), view)
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runExplain prints an English description of the expression
+//
+// visualcron explain "<expression>"
+func runExplain(args []string) int {
+	fs := newFlagSet("explain")
+
+	c, ok := parseCommand(fs, args)
+	if !ok {
+		return 1
+	}
+
+	log.Print(c.Describe())
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runLint checks the expression, or every job in a crontab, against
+// the lint rules and prints what it finds. It fails when a finding is
+// at least as severe as -fail-on, or a job can't be parsed
+//
+// visualcron lint [-disable <rules>] [-fail-on warning] "<expression>"
+// visualcron lint [-disable <rules>] [-fail-on warning] -f <crontab>
+func runLint(args []string) int {
+	fs := newFlagSet("lint")
+	file := fs.String("f", "", "crontab file to lint, or - for stdin")
+	disable := fs.String("disable", "", "comma separated rules not to run")
+	failOn := fs.String("fail-on", "warning", "lowest severity that fails (info, warning, error)")
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	severity, err := cron.ParseSeverity(*failOn)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	var lintOpts cron.LintOptions
+	if *disable != "" {
+		lintOpts.Disable = strings.Split(*disable, ",")
+	}
+	if err := cron.CheckRules(lintOpts.Disable); err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	if *file != "" {
+		return lintCrontab(*file, opts, lintOpts, severity)
+	}
+
+	c, ok := parseExpressionArg(fs, opts)
+	if !ok {
+		return 1
+	}
+	printWarnings(c.Warnings, "")
+
+	findings := c.Lint(lintOpts)
+	if len(findings) == 0 {
+		log.Print("no problems found")
+		return 0
+	}
+
+	return printFindings(findings, "", severity)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintCrontab lints every job in a crontab, followed by the lines
+// that could not be parsed
+func lintCrontab(path string, opts cron.ParseOptions, lintOpts cron.LintOptions, severity cron.Severity) int {
+	crontab, err := readCrontab(path, opts)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	code := 0
+	found := false
+	for _, entry := range crontab.Entries {
+		prefix := fmt.Sprintf("line %d - ", entry.Line)
+		printWarnings(entry.Cron.Warnings, prefix)
+
+		findings := entry.Lint(lintOpts)
+		if len(findings) > 0 {
+			found = true
+			if printFindings(findings, prefix, severity) != 0 {
+				code = 1
+			}
+		}
+	}
+
+	for _, err := range crontab.Errors {
+		printError(err)
+		code = 1
+	}
+
+	if !found && len(crontab.Errors) == 0 {
+		log.Print("no problems found")
+	}
+
+	return code
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printFindings outputs one finding per line and returns 1 when any
+// of them is at least as severe as severity
+//
+//	warning - line 3 - day-or - runs on days matching ...
+func printFindings(findings []cron.Finding, prefix string, severity cron.Severity) int {
+	code := 0
+
+	var sb strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&sb, "%s - %s%s - %s\n", f.Severity, prefix, f.Rule, f.Message)
+		if f.Severity >= severity {
+			code = 1
+		}
+	}
+
+	log.Print(sb.String())
+
+	return code
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runOverlap reports when the expressions, or the jobs in a crontab,
+// run together over a horizon: the instants several of them start at,
+// the minutes with the most jobs running and the busiest windows
+//
+// visualcron overlap [-from <time>] [-for 24h] [-duration 0s] [-window 1h] [-top 5] "<expression>"...
+// visualcron overlap [-from <time>] [-for 24h] [-duration 0s] [-window 1h] [-top 5] -f <crontab>
+func runOverlap(args []string) int {
+	fs := newFlagSet("overlap")
+	file := fs.String("f", "", "crontab file to check, or - for stdin")
+	from := fs.String("from", "", "start of the horizon (default now)")
+	horizon := fs.Duration("for", 24*time.Hour, "length of the horizon")
+	duration := fs.Duration("duration", 0, "how long each job runs for, unless a visualcron:duration comment above it says otherwise")
+	window := fs.Duration("window", time.Hour, "length of the windows runs are counted in")
+	top := fs.Int("top", 5, "number of collisions, busy spans and windows to print")
+	tz := tzFlag(fs)
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	switch {
+	case *horizon <= 0:
+		log.Print("error - -for must be more than 0")
+		return 1
+	case *window <= 0:
+		log.Print("error - -window must be more than 0")
+		return 1
+	case *duration < 0:
+		log.Print("error - -duration can't be negative")
+		return 1
+	case *top <= 0:
+		log.Print("error - -top must be more than 0")
+		return 1
+	}
+
+	view, ok := parseViewZone(*tz)
+	if !ok {
+		return 1
+	}
+
+	start, err := ParseTimeIn(*from, time.Now().Truncate(time.Minute), view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	entries, errs, ok := readEntries(fs, *file, opts)
+	if !ok {
+		return 1
+	}
+
+	jobs := make([]cron.Job, len(entries))
+	for i, entry := range entries {
+		d, err := entry.Duration(*duration)
+		if err != nil {
+			log.Printf("error - line %d - %s", entry.Line, err.Error())
+			return 1
+		}
+		jobs[i] = cron.Job{Cron: entry.Cron, Duration: d}
+	}
+
+	overlap := cron.Overlaps(jobs, start, start.Add(*horizon), cron.OverlapOptions{Window: *window, Top: *top})
+	log.Print(overlapReport(jobs, overlap, *window, *top))
+
+	return printErrors(errs)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// overlapReport formats an overlap report, listing the jobs by number
+// and then at most top collisions
+//
+//	1  0 0 * * * /usr/bin/backup  (10m0s)
+//	2  */15 * * * * /usr/bin/poll
+//
+//	97 runs from Sat 2024-06-15 00:00:00 UTC to Sun 2024-06-16 00:00:00 UTC
+//
+//	2 collisions
+//	Sat 2024-06-15 00:00:00 UTC  1, 2
+func overlapReport(jobs []cron.Job, overlap cron.Overlap, window time.Duration, top int) string {
+	var sb strings.Builder
+
+	for i, job := range jobs {
+		fmt.Fprintf(&sb, "%d  %s", i+1, job.Cron.Original)
+		if job.Duration > 0 {
+			fmt.Fprintf(&sb, "  (%s)", job.Duration)
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\n%s from %s to %s\n", plural(overlap.Runs, "run"), overlap.From.Format(timeLayout), overlap.To.Format(timeLayout))
+
+	sb.WriteString("\n")
+	if len(overlap.Collisions) == 0 {
+		sb.WriteString("no collisions\n")
+	} else {
+		sb.WriteString(plural(len(overlap.Collisions), "collision") + "\n")
+		for i, c := range overlap.Collisions {
+			if i == top {
+				fmt.Fprintf(&sb, "... and %d more\n", len(overlap.Collisions)-top)
+				break
+			}
+			fmt.Fprintf(&sb, "%s  %s\n", c.Time.Format(timeLayout), jobList(c.Jobs))
+		}
+	}
+
+	sb.WriteString("\n")
+	if len(overlap.Busy) == 0 {
+		fmt.Fprintf(&sb, "peak %s running at once\n", plural(overlap.Peak, "job"))
+	} else {
+		fmt.Fprintf(&sb, "peak %s running at once, busiest minutes\n", plural(overlap.Peak, "job"))
+		for _, span := range overlap.Busy {
+			fmt.Fprintf(&sb, "%s for %s  %s\n", span.Start.Format(timeLayout), span.End.Sub(span.Start), jobList(span.Jobs))
+		}
+	}
+
+	if len(overlap.Windows) > 0 {
+		fmt.Fprintf(&sb, "\nbusiest %s windows\n", window)
+		for _, w := range overlap.Windows {
+			fmt.Fprintf(&sb, "%s  %s from %s\n", w.Start.Format(timeLayout), plural(w.Runs, "run"), jobList(w.Jobs))
+		}
+	}
+
+	return sb.String()
+}
+
+// plural returns n followed by the noun, with an s unless n is 1
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// jobList joins the numbers of jobs (ex 1, 2, 3)
+func jobList(ids []int) string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.Itoa(id)
+	}
+	return strings.Join(result, ", ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runExport prints the runs of the expressions, or of every job in a
+// crontab, between two times in a format calendar apps can import
+//
+// visualcron export [-format ics] [-from <time>] [-to <time>] [-expand] "<expression>"...
+// visualcron export [-format ics] [-from <time>] [-to <time>] [-expand] -f <crontab>
+func runExport(args []string) int {
+	fs := newFlagSet("export")
+	file := fs.String("f", "", "crontab file to export, or - for stdin")
+	format := fs.String("format", "ics", "export format (ics)")
+	from := fs.String("from", "", "start of the export (inclusive, default now)")
+	to := fs.String("to", "", "end of the export (inclusive, default a month after -from)")
+	expand := fs.Bool("expand", false, "write an event for every run, instead of a recurring event where possible")
+	tz := tzFlag(fs)
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	if *format != "ics" {
+		log.Printf("error - export - unknown format - %s", *format)
+		return 1
+	}
+
+	view, ok := parseViewZone(*tz)
+	if !ok {
+		return 1
+	}
+
+	start, err := ParseTimeIn(*from, time.Now(), view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	end, err := ParseTimeIn(*to, start.AddDate(0, 1, 0), view)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	if end.Before(start) {
+		log.Print("error - -to is before -from")
+		return 1
+	}
+
+	entries, errs, ok := readEntries(fs, *file, opts)
+	if !ok {
+		return 1
+	}
+
+	crons := make([]*cron.Cron, len(entries))
+	for i, entry := range entries {
+		crons[i] = entry.Cron
+	}
+
+	printOutput(cron.ICalendar(crons, start, end, cron.ICalOptions{Expand: *expand}))
+
+	return printErrors(errs)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runConvert converts the expression to a systemd timer, printing its
+// OnCalendar= specs and a .timer and .service unit pair, to an AWS
+// EventBridge schedule, or to a cron expression from a systemd
+// OnCalendar spec or EventBridge schedule. What the other format can't
+// express is an error, and day of week numbers that stand for other
+// days in it are a warning. The converted schedule is written to
+// stdout, so it can be redirected to a file
+//
+// visualcron convert [-to systemd] [-name <unit>] "<expression>"
+// visualcron convert -to aws "<expression>"
+// visualcron convert -to cron "<OnCalendar spec, EventBridge schedule or expression>"
+func runConvert(args []string) int {
+	fs := newFlagSet("convert")
+	to := fs.String("to", "systemd", "format to convert to (systemd, aws, cron)")
+	name := fs.String("name", "", "name of the systemd units (default the command's name)")
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	switch *to {
+	case "systemd", "aws":
+	case "cron":
+		// EventBridge schedules are detected, but OnCalendar specs never are
+		if opts.Dialect == cron.DialectAuto && isOnCalendarSpec(fs.Arg(0)) {
+			opts.Dialect = cron.DialectSystemd
+		}
+	default:
+		log.Printf("error - convert - unknown format - %s", *to)
+		return 1
+	}
+
+	c, ok := parseExpressionArg(fs, opts)
+	if !ok {
+		return 1
+	}
+	printWarnings(c.Warnings, "")
+
+	switch *to {
+	case "cron":
+		exp, err := c.Expression()
+		if err != nil {
+			log.Printf("error - %s", err.Error())
+			return 1
+		}
+
+		printOutput(exp)
+
+		// Quartz is detected from its ?, but seconds have to be asked for
+		fields := strings.Fields(exp)
+		if c.Location != nil {
+			fields = fields[1:]
+		}
+		target := cron.DialectStandard
+		switch {
+		case len(c.Year) > 0:
+			target = cron.DialectQuartz
+			log.Print("warning - the expression has a year, which standard cron has no field for, so it is Quartz and read with -dialect quartz")
+		case len(fields) == 6:
+			target = cron.DialectSeconds
+			log.Print("warning - the expression has seconds, so it is read with -dialect seconds")
+		}
+		printWeekdayShift(c, target)
+		return 0
+
+	case "aws":
+		exp, err := c.AWSExpression()
+		if err != nil {
+			log.Printf("error - %s", err.Error())
+			return 1
+		}
+
+		printOutput(exp)
+		printWeekdayShift(c, cron.DialectAWS)
+		return 0
+	}
+
+	// Without a command there are no units to run it
+	if strings.TrimSpace(c.Command) == "" {
+		specs, err := c.OnCalendar()
+		if err != nil {
+			log.Printf("error - %s", err.Error())
+			return 1
+		}
+
+		for _, spec := range specs {
+			printOutput("OnCalendar=" + spec)
+		}
+		return 0
+	}
+
+	unit := *name
+	if unit == "" {
+		unit = unitName(c.Command)
+	}
+
+	timer, service, err := c.SystemdUnits(unit)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	printOutput(fmt.Sprintf("# %s.timer\n%s\n# %s.service\n%s", unit, timer, unit, service))
+
+	return 0
+}
+
+// isAWSSchedule checks if an expression is an EventBridge cron() or
+// rate() schedule
+func isAWSSchedule(exp string) bool {
+	exp = strings.TrimSpace(exp)
+	return strings.HasPrefix(exp, "cron(") || strings.HasPrefix(exp, "rate(")
+}
+
+// isOnCalendarSpec checks if an expression reads as a systemd
+// OnCalendar spec rather than cron, as a spec has at most a weekday,
+// date, time and time zone (ex Mon *-*-* 09:00 UTC) while cron has at
+// least 5 fields or starts with a macro
+func isOnCalendarSpec(exp string) bool {
+	if isAWSSchedule(exp) || strings.HasPrefix(strings.TrimSpace(exp), "@") {
+		return false
+	}
+	return len(strings.Fields(exp)) < 5
+}
+
+// printWeekdayShift warns when the day of week numbers of the
+// expression stand for other days in the dialect it was converted to
+func printWeekdayShift(c *cron.Cron, to cron.Dialect) {
+	if warning := c.WeekdayShift(to); warning != "" {
+		log.Printf("warning - %s", warning)
+	}
+}
+
+// unitName returns a systemd unit name for the command, from the name
+// of the program it runs (ex backup for /usr/local/bin/backup.sh -v)
+func unitName(command string) string {
+	program := filepath.Base(strings.Fields(command)[0])
+	program = strings.TrimSuffix(program, filepath.Ext(program))
+
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, program)
+
+	if name == "" {
+		return "visualcron"
+	}
+	return name
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// manifestFile is the serialised form of the CronJobs in one manifest
+type manifestFile struct {
+	File     string         `json:"file" yaml:"file"`
+	CronJobs []cron.CronJob `json:"cronjobs" yaml:"cronjobs"`
+	Errors   []string       `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// runK8s finds the Kubernetes CronJobs in a manifest, or in the YAML
+// files of a directory, and prints each one's line, namespace, name
+// and settings above its schedule as a table. The files and CronJobs
+// that could not be parsed follow
+//
+// The schedules are read offline from the files, so Helm charts are
+// rendered first (ex helm template . | visualcron k8s -f -)
+//
+// visualcron k8s [-output table] -f <manifest or directory>
+func runK8s(args []string) int {
+	fs := newFlagSet("k8s")
+	path := fs.String("f", "", "manifest or directory of manifests to read, or - for stdin")
+	output := fs.String("output", "table", "output format (table, json, yaml)")
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	if *path == "" {
+		log.Print("error - k8s - -f is required")
+		return 1
+	}
+
+	format, err := ParseFormat(*output)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	files, err := manifestFiles(*path)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	var (
+		out  []manifestFile
+		errs []error
+	)
+	for _, file := range files {
+		manifest, err := readManifest(file, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", file, err))
+			continue
+		}
+
+		mf := manifestFile{File: file, CronJobs: manifest.CronJobs}
+		for _, err := range manifest.Errors {
+			errs = append(errs, fmt.Errorf("%s - %w", file, err))
+			mf.Errors = append(mf.Errors, err.Error())
+		}
+		if len(mf.CronJobs) > 0 || len(mf.Errors) > 0 {
+			out = append(out, mf)
+		}
+	}
+
+	if format != FormatTable {
+		if out == nil {
+			out = []manifestFile{}
+		}
+		if code := printMarshal(out, format); code != 0 || len(errs) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	var tables []string
+	for _, mf := range out {
+		for _, job := range mf.CronJobs {
+			tables = append(tables, "# "+mf.File+"\n"+job.Header()+job.Cron.Table())
+		}
+	}
+
+	if len(tables) > 0 {
+		log.Print(strings.Join(tables, "\n"))
+	} else if len(errs) == 0 {
+		log.Print("no cronjobs found")
+	}
+
+	for _, mf := range out {
+		for _, job := range mf.CronJobs {
+			printWarnings(job.Cron.Warnings, fmt.Sprintf("%s - line %d - ", mf.File, job.Line))
+		}
+	}
+
+	return printErrors(errs)
+}
+
+// manifestFiles returns path when it is a file, or else the .yaml and
+// .yml files under it, skipping hidden directories (ex .git)
+func manifestFiles(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("k8s - %s", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(name string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if name != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("k8s - %s", err)
+	}
+
+	return files, nil
+}
+
+// readManifest parses the manifest at path, with - reading stdin
+func readManifest(path string, opts cron.ParseOptions) (*cron.Manifest, error) {
+	if path == "-" {
+		return cron.ParseManifest(os.Stdin, opts)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("manifest - %s", err)
+	}
+	defer f.Close()
+
+	return cron.ParseManifest(f, opts)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runCI finds the schedules in the GitHub Actions workflows under a
+// directory, or in a workflow or GitLab pipeline schedule export, and
+// prints each one's line, platform and name above its schedule as a
+// table. What the platform rejects follows, then the files and
+// schedules that could not be parsed. It fails when a finding is at
+// least as severe as -fail-on
+//
+// visualcron ci [-fail-on warning] -f <directory, workflow or export>
+func runCI(args []string) int {
+	fs := newFlagSet("ci")
+	path := fs.String("f", "", "directory with .github/workflows, workflow or GitLab schedule export to read, or - for stdin")
+	failOn := fs.String("fail-on", "warning", "lowest severity that fails (info, warning, error)")
+
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return 1
+	}
+
+	if *path == "" {
+		log.Print("error - ci - -f is required")
+		return 1
+	}
+
+	severity, err := cron.ParseSeverity(*failOn)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	files, err := workflowFiles(*path)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	var (
+		schedules []ciSchedule
+		errs      []error
+	)
+	for _, file := range files {
+		config, err := readCIConfig(file, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", file, err))
+			continue
+		}
+
+		for _, schedule := range config.Schedules {
+			schedules = append(schedules, ciSchedule{file, schedule})
+		}
+		for _, err := range config.Errors {
+			errs = append(errs, fmt.Errorf("%s - %w", file, err))
+		}
+	}
+
+	if len(schedules) > 0 {
+		tables := make([]string, len(schedules))
+		for i, s := range schedules {
+			tables[i] = "# " + s.file + "\n" + s.Header() + s.Cron.Table()
+		}
+		log.Print(strings.Join(tables, "\n"))
+	} else if len(errs) == 0 {
+		log.Print("no schedules found")
+	}
+
+	code := 0
+	for _, s := range schedules {
+		prefix := fmt.Sprintf("%s - line %d - ", s.file, s.Line)
+		printWarnings(s.Cron.Warnings, prefix)
+
+		if findings := s.Findings(); len(findings) > 0 && printFindings(findings, prefix, severity) != 0 {
+			code = 1
+		}
+	}
+
+	if printErrors(errs) != 0 {
+		code = 1
+	}
+
+	return code
+}
+
+// ciSchedule is a CI schedule and the file it is in
+type ciSchedule struct {
+	file string
+	cron.CISchedule
+}
+
+// workflowFiles returns path when it is a file, or else the GitHub
+// Actions workflows under it, in .github/workflows directories
+func workflowFiles(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("ci - %s", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(name string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dir := filepath.Dir(name)
+		if ext := filepath.Ext(name); (ext == ".yaml" || ext == ".yml") && filepath.Base(dir) == "workflows" && filepath.Base(filepath.Dir(dir)) == ".github" {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ci - %s", err)
+	}
+
+	return files, nil
+}
+
+// readCIConfig parses the CI config at path, with - reading stdin
+func readCIConfig(path string, opts cron.ParseOptions) (*cron.CIConfig, error) {
+	if path == "-" {
+		return cron.ParseCIConfig(os.Stdin, opts)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ci - %s", err)
+	}
+	defer f.Close()
+
+	return cron.ParseCIConfig(f, opts)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// tzFlag adds the -tz flag to the commands that print run times
+func tzFlag(fs *flag.FlagSet) *string {
+	return fs.String("tz", "", "time zone to read times in and show run times in (default local)")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseViewZone returns the time zone named by the -tz flag, or nil
+// when it is not set
+//
+// Errors are logged, so the caller only needs to check ok
+func parseViewZone(name string) (loc *time.Location, ok bool) {
+	if name == "" {
+		return nil, true
+	}
+
+	loc, err := cron.ParseLocation(name)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return nil, false
+	}
+
+	return loc, true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// newFlagSet creates a flag set for a command, writing usage and
+// errors to the log
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(log.Writer())
+	return fs
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseCommand parses the flags of a command and the expression
+// that follows them
+//
+// Errors and warnings are logged, so the caller only needs to check ok
+func parseCommand(fs *flag.FlagSet, args []string) (c *cron.Cron, ok bool) {
+	opts, ok := parseFlags(fs, args)
+	if !ok {
+		return nil, false
+	}
+
+	c, ok = parseExpressionArg(fs, opts)
+	if ok {
+		printWarnings(c.Warnings, "")
+	}
+
+	return c, ok
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseFlags parses the flags of a command into the options used to
+// parse expressions. Flags that change how an expression is parsed
+// are added to every command here
+//
+// Errors are logged, so the caller only needs to check ok
+func parseFlags(fs *flag.FlagSet, args []string) (opts cron.ParseOptions, ok bool) {
+	dialectName := fs.String("dialect", "auto", "expression dialect (auto, standard, seconds, quartz, systemd, aws)")
+	system := fs.Bool("system", false, "expressions have a user field before the command, as in /etc/crontab")
+	dayMatch := fs.String("day-match", "or", "how a restricted day of month and day of week combine (or, and)")
+	dst := fs.String("dst", "vixie", "how runs in a daylight saving time change are handled (vixie, wall)")
+	lenient := fs.Bool("lenient", false, "warn about unrecognised tokens instead of failing")
+
+	// The flag set logs its own errors
+	if err := fs.Parse(args); err != nil {
+		return opts, false
+	}
+
+	dialect, err := cron.ParseDialect(*dialectName)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return opts, false
+	}
+	match, err := cron.ParseDayMatch(*dayMatch)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return opts, false
+	}
+
+	policy, err := cron.ParseDSTPolicy(*dst)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return opts, false
+	}
+
+	opts.Dialect = dialect
+	opts.System = *system
+	opts.DayMatch = match
+	opts.DST = policy
+	opts.Lenient = *lenient
+
+	return opts, true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseExpressionArg parses the expression left after the flags
+//
+// Errors are logged, so the caller only needs to check ok
+func parseExpressionArg(fs *flag.FlagSet, opts cron.ParseOptions) (c *cron.Cron, ok bool) {
+	if !argsValidation(fs.Args()) {
+		log.Print("error - invalid input")
+		return nil, false
+	}
+
+	c, err := cron.ParseWithOptions(fs.Arg(0), opts)
+	if err != nil {
+		printError(err)
+		return nil, false
+	}
+
+	return c, true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// readEntries parses every expression left after the flags, or every
+// job in the crontab at path, printing their warnings. The crontab
+// lines that could not be parsed are returned to print after the
+// output
+func readEntries(fs *flag.FlagSet, path string, opts cron.ParseOptions) (entries []cron.Entry, errs []error, ok bool) {
+	if path != "" {
+		crontab, err := readCrontab(path, opts)
+		if err != nil {
+			log.Printf("error - %s", err.Error())
+			return nil, nil, false
+		}
+
+		for _, entry := range crontab.Entries {
+			printWarnings(entry.Cron.Warnings, fmt.Sprintf("line %d - ", entry.Line))
+		}
+		return crontab.Entries, crontab.Errors, true
+	}
+
+	if !argsValidation(fs.Args()) {
+		log.Print("error - invalid input")
+		return nil, nil, false
+	}
+
+	for _, exp := range fs.Args() {
+		c, err := cron.ParseWithOptions(exp, opts)
+		if err != nil {
+			printError(err)
+			return nil, nil, false
+		}
+
+		printWarnings(c.Warnings, "")
+		entries = append(entries, cron.Entry{Cron: c})
+	}
+
+	return entries, nil, true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// readCrontab parses the crontab at path, with - reading stdin
+//
+// /etc/crontab and the files in /etc/cron.d are always read as system
+// crontabs
+func readCrontab(path string, opts cron.ParseOptions) (*cron.Crontab, error) {
+	if path == "-" {
+		return cron.ParseCrontab(os.Stdin, opts)
+	}
+
+	if isSystemCrontab(path) {
+		opts.System = true
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("crontab - %s", err)
+	}
+	defer f.Close()
+
+	return cron.ParseCrontab(f, opts)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printOccurrences outputs one run time per line
+//
+// When the schedule has its own time zone each time is also shown in
+// the viewer's zone, which is view or local time. Runs changed by
+// daylight saving time are flagged
+func printOccurrences(c *cron.Cron, occurrences []cron.Occurrence, view *time.Location) {
+	if len(occurrences) == 0 {
+		log.Print("no run times found")
+		return
+	}
+
+	if view == nil {
+		view = time.Local
+	}
+
+	var sb strings.Builder
+	for _, o := range occurrences {
+		sb.WriteString(o.Time.Format(timeLayout))
+		if c.Location != nil {
+			sb.WriteString("  " + o.Time.In(view).Format(timeLayout))
+		}
+
+		switch o.Change {
+		case cron.DSTShifted:
+			sb.WriteString("  shifted from " + o.Wall.Format("15:04:05") + ", clocks went forward")
+		case cron.DSTSkipped:
+			sb.WriteString("  skipped " + o.Wall.Format("15:04:05") + ", clocks went forward")
+		case cron.DSTRepeated:
+			sb.WriteString("  repeated, clocks went back")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	log.Print(sb.String())
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printError outputs an error. A parse error is followed by the
+// expression, a caret under the part that failed and a hint
+//
+//	error - parsing error - hour - invalid
+//	  0 1,2,30 * * * /cmd
+//	        ^~
+//	  hint: hour must be 0-23
+//
+// Each of several parse errors is output this way, keeping anything
+// they were wrapped with (ex the crontab line)
+func printError(err error) {
+	var errs cron.ParseErrors
+	if errors.As(err, &errs) {
+		prefix := strings.TrimSuffix(err.Error(), errs.Error())
+		for _, pe := range errs {
+			printParseError("error", prefix, pe)
+		}
+		return
+	}
+
+	var pe *cron.ParseError
+	if !errors.As(err, &pe) {
+		log.Printf("error - %s", err.Error())
+		return
+	}
+
+	printParseError("error", strings.TrimSuffix(err.Error(), pe.Error()), pe)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printErrors outputs each error and returns 1 when there are any
+func printErrors(errs []error) int {
+	for _, err := range errs {
+		printError(err)
+	}
+
+	if len(errs) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printWarnings outputs the tokens skipped when parsing leniently, in
+// the same form as errors
+func printWarnings(warnings cron.ParseErrors, prefix string) {
+	for _, pe := range warnings {
+		printParseError("warning", prefix, pe)
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printParseError outputs a parse error with its caret and hint,
+// labelled as level
+func printParseError(level, prefix string, pe *cron.ParseError) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s - %s%s\n", level, prefix, pe.Error())
+	fmt.Fprintf(&sb, "  %s\n", pe.Expression)
+	fmt.Fprintf(&sb, "  %s\n", caret(pe))
+	if pe.Hint != "" {
+		fmt.Fprintf(&sb, "  hint: %s\n", pe.Hint)
+	}
+
+	log.Print(sb.String())
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// caret returns the line that marks the token of a parse error, lined
+// up under the expression. Tabs are kept so the line up survives them
+func caret(pe *cron.ParseError) string {
+	column := pe.Column
+	if column > len(pe.Expression) {
+		column = len(pe.Expression)
+	}
+
+	var sb strings.Builder
+	for _, r := range pe.Expression[:column] {
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+
+	sb.WriteString("^")
+	if n := utf8.RuneCountInString(pe.Token); n > 1 {
+		sb.WriteString(strings.Repeat("~", n-1))
+	}
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// printMarshal outputs v as JSON or YAML, to stdout
+func printMarshal(v interface{}, format Format) int {
+	out, err := Marshal(v, format)
+	if err != nil {
+		log.Printf("error - %s", err.Error())
+		return 1
+	}
+
+	printOutput(out)
+
+	return 0
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// isSystemCrontab checks if a path is /etc/crontab or in /etc/cron.d
+func isSystemCrontab(path string) bool {
+	path = filepath.Clean(path)
+	return path == "/etc/crontab" || filepath.Dir(path) == "/etc/cron.d"
+}
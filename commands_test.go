@@ -0,0 +1,852 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"visualcron/cron"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Run(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{"No_Args", []string{}, 1, "error - invalid input\n"},
+		{"Table", []string{"*/15 0 1,15 * 1-5 /usr/bin/find"}, 0, `minute        0 15 30 45
+hour          0
+day of month  1 15
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   1 2 3 4 5
+days          day of month or day of week
+command       /usr/bin/find
+`},
+		{"Table_Invalid", []string{"1 2 3 4"}, 1, `error - not enough parts in the cron expression
+  1 2 3 4
+         ^
+  hint: expected 5 time fields followed by a command
+`},
+		{"Table_Invalid_Item", []string{"0 1,2,30 * * * /cmd"}, 1, `error - parsing error - hour - invalid
+  0 1,2,30 * * * /cmd
+        ^~
+  hint: hour must be 0-23
+`},
+		{"Table_Unrecognised", []string{"5,abc x * * * /cmd"}, 1, `error - parsing error - minute - unrecognised - abc
+  5,abc x * * * /cmd
+    ^~~
+  hint: use 0-59, a range (ex 1-5) or a step (ex */15), separated by commas
+error - parsing error - hour - unrecognised - x
+  5,abc x * * * /cmd
+        ^
+  hint: use 0-23, a range (ex 1-5) or a step (ex */15), separated by commas
+`},
+		{"Table_Lenient", []string{"-lenient", "5,abc 0 * * * /cmd"}, 0, `warning - parsing error - minute - unrecognised - abc
+  5,abc 0 * * * /cmd
+    ^~~
+  hint: use 0-59, a range (ex 1-5) or a step (ex */15), separated by commas
+minute        5
+hour          0
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+command       /cmd
+`},
+		{"Explain_Lenient", []string{"explain", "-lenient", "5,abc 0 * * * /cmd"}, 0, `warning - parsing error - minute - unrecognised - abc
+  5,abc 0 * * * /cmd
+    ^~~
+  hint: use 0-59, a range (ex 1-5) or a step (ex */15), separated by commas
+At 00:05.
+`},
+		{"Table_Dialect", []string{"-dialect", "seconds", "*/30 0 9 1 1 1"}, 0, `second        0 30
+minute        0
+hour          9
+day of month  1
+month         1
+day of week   1
+days          day of month or day of week
+command       
+`},
+		{"Table_System", []string{"-system", "0 2 1 1 1 root /usr/bin/backup"}, 0, `minute        0
+hour          2
+day of month  1
+month         1
+day of week   1
+days          day of month or day of week
+user          root
+command       /usr/bin/backup
+`},
+		{"Table_Output_YAML", []string{"-output", "yaml", "0 2 1 1 1 /usr/bin/backup"}, 0, `original: 0 2 1 1 1 /usr/bin/backup
+kind: time
+minute: [0]
+hour: [2]
+day_of_month: [1]
+month: [1]
+day_of_week: [1]
+day_match: or
+command: /usr/bin/backup
+`},
+		{"Table_Day_Match", []string{"-day-match", "and", "0 0 1 * MON /cmd"}, 0, `minute        0
+hour          0
+day of month  1
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   1
+days          day of month and day of week
+command       /cmd
+`},
+		{"Table_Invalid_Day_Match", []string{"-day-match", "xor", "* * * * * /cmd"}, 1, "error - day match - unknown - xor\n"},
+		{"Table_Invalid_Output", []string{"-output", "xml", "* * * * * /cmd"}, 1, "error - output - unknown - xml\n"},
+		{"Table_Invalid_Dialect", []string{"-dialect", "spring", "* * * * * *"}, 1, "error - dialect - unknown - spring\n"},
+		{"Next", []string{"next", "-n", "3", "-from", "2022-06-14T01:00:00Z", "*/15 0 1,15 * 1-5 /usr/bin/find"}, 0, `Wed 2022-06-15 00:00:00 UTC
+Wed 2022-06-15 00:15:00 UTC
+Wed 2022-06-15 00:30:00 UTC
+`},
+		{"Next_Time_Zone", []string{"next", "-n", "2", "-tz", "America/New_York", "-from", "2022-06-14T00:00", "CRON_TZ=Europe/London 0 9 * * MON /cmd"}, 0, `Mon 2022-06-20 09:00:00 BST  Mon 2022-06-20 04:00:00 EDT
+Mon 2022-06-27 09:00:00 BST  Mon 2022-06-27 04:00:00 EDT
+`},
+		{"Next_View_Zone", []string{"next", "-n", "1", "-tz", "Asia/Tokyo", "-from", "2022-06-14T00:00", "0 9 * * * /cmd"}, 0, "Tue 2022-06-14 09:00:00 JST\n"},
+		{"Next_Invalid_Zone", []string{"next", "-tz", "Mars/Olympus", "* * * * * /cmd"}, 1, "error - time zone - invalid - Mars/Olympus\n"},
+		{"Next_DST_Vixie", []string{"next", "-n", "2", "-tz", "UTC", "-from", "2022-03-26T12:00", "CRON_TZ=Europe/London 30 1 * * * /cmd"}, 0, `Sun 2022-03-27 02:00:00 BST  Sun 2022-03-27 01:00:00 UTC  shifted from 01:30:00, clocks went forward
+Mon 2022-03-28 01:30:00 BST  Mon 2022-03-28 00:30:00 UTC
+`},
+		{"Next_DST_Wall", []string{"next", "-n", "1", "-dst", "wall", "-tz", "UTC", "-from", "2022-03-26T12:00", "CRON_TZ=Europe/London 30 1 * * * /cmd"}, 0, `Sun 2022-03-27 02:00:00 BST  Sun 2022-03-27 01:00:00 UTC  skipped 01:30:00, clocks went forward
+Mon 2022-03-28 01:30:00 BST  Mon 2022-03-28 00:30:00 UTC
+`},
+		{"Next_DST_Repeated", []string{"next", "-n", "2", "-dst", "wall", "-tz", "UTC", "-from", "2022-10-29T12:00", "CRON_TZ=Europe/London 30 1 * * * /cmd"}, 0, `Sun 2022-10-30 01:30:00 BST  Sun 2022-10-30 00:30:00 UTC
+Sun 2022-10-30 01:30:00 GMT  Sun 2022-10-30 01:30:00 UTC  repeated, clocks went back
+`},
+		{"Next_Invalid_DST", []string{"next", "-dst", "utc", "* * * * * /cmd"}, 1, "error - dst - unknown - utc\n"},
+		{"Next_Never", []string{"next", "0 0 30 2 * /cmd"}, 0, "no run times found\n"},
+		{"Next_No_Expression", []string{"next", "-n", "3"}, 1, "error - invalid input\n"},
+		{"Next_Negative", []string{"next", "-n", "-1", "* * * * * /cmd"}, 1, "error - -n must be more than 0\n"},
+		{"Prev_Zero", []string{"prev", "-n", "0", "* * * * * /cmd"}, 1, "error - -n must be more than 0\n"},
+		{"Next_Invalid_Time", []string{"next", "-from", "yesterday", "* * * * * /cmd"}, 1, "error - time - invalid - yesterday\n"},
+		{"Prev", []string{"prev", "-n", "2", "-from", "2022-06-15T00:20:00Z", "*/15 0 1,15 * 1-5 /usr/bin/find"}, 0, `Wed 2022-06-15 00:15:00 UTC
+Wed 2022-06-15 00:00:00 UTC
+`},
+		{"Between", []string{"between", "-from", "2022-06-15T00:10:00Z", "-to", "2022-06-15T00:45:00Z", "*/15 0 1,15 * 1-5 /usr/bin/find"}, 0, `Wed 2022-06-15 00:15:00 UTC
+Wed 2022-06-15 00:30:00 UTC
+Wed 2022-06-15 00:45:00 UTC
+`},
+		{"Explain", []string{"explain", "*/15 0 1,15 * 1-5 /usr/bin/find"}, 0, "At every 15th minute past hour 0 on day-of-month 1 and 15 or on every day-of-week from Monday through Friday.\n"},
+		{"Explain_Invalid", []string{"explain", "61 * * * * /cmd"}, 1, `error - parsing error - minute - invalid
+  61 * * * * /cmd
+  ^~
+  hint: minute must be 0-59
+`},
+		{"Explain_Seconds_First", []string{"explain", "0 30 9 * * MON-FRI /x"}, 1, `error - parsing error - hour - invalid
+  0 30 9 * * MON-FRI /x
+    ^~
+  hint: hour must be 0-23, or use -dialect seconds when the first of 6 time fields is seconds
+`},
+		{"Between_Missing_To", []string{"between", "-from", "2022-06-15", "* * * * * /cmd"}, 1, "error - -from and -to are required\n"},
+		{"Calendar", []string{"-view", "calendar", "-month", "2024-02", "CRON_TZ=UTC 0 9 29 2 * /usr/bin/report"}, 0, `           February 2024
+   Su   Mo   Tu   We   Th   Fr   Sa
+                        1    2    3
+    4    5    6    7    8    9   10
+   11   12   13   14   15   16   17
+   18   19   20   21   22   23   24
+   25   26   27   28 [29]
+                       1
+1 day, 1 run
+`},
+		{"Calendar_Invalid_Month", []string{"-view", "calendar", "-month", "2024-13", "0 9 * * * /cmd"}, 1, "error - calendar - invalid month - 2024-13\n"},
+		{"Calendar_Month_And_Year", []string{"-view", "calendar", "-month", "2024-02", "-year", "2024", "0 9 * * * /cmd"}, 1, "error - calendar - use one of -month or -year\n"},
+		{"Calendar_JSON", []string{"-view", "calendar", "-output", "json", "0 9 * * * /cmd"}, 1, "error - view - calendar only works with table output\n"},
+		{"Heatmap_Invalid_Bucket", []string{"-view", "heatmap", "-bucket", "7", "0 9 * * * /cmd"}, 1, "error - heatmap - bucket must divide an hour - 7\n"},
+		{"Heatmap_JSON", []string{"-view", "heatmap", "-output", "json", "0 9 * * * /cmd"}, 1, "error - view - heatmap only works with table output\n"},
+		{"Heatmap_Invalid", []string{"-view", "heatmap", "0 9 * * * /a", "61 * * * * /b"}, 1, `error - parsing error - minute - invalid
+  61 * * * * /b
+  ^~
+  hint: minute must be 0-59
+`},
+		{"Overlap", []string{"overlap", "-tz", "UTC", "-from", "2024-06-15", "-for", "1h", "-duration", "20m", "CRON_TZ=UTC 0 0 * * * /a", "CRON_TZ=UTC 10,30 0 * * * /b"}, 0, `1  CRON_TZ=UTC 0 0 * * * /a  (20m0s)
+2  CRON_TZ=UTC 10,30 0 * * * /b  (20m0s)
+
+3 runs from Sat 2024-06-15 00:00:00 UTC to Sat 2024-06-15 01:00:00 UTC
+
+no collisions
+
+peak 2 jobs running at once, busiest minutes
+Sat 2024-06-15 00:10:00 UTC for 10m0s  1, 2
+
+busiest 1h0m0s windows
+Sat 2024-06-15 00:00:00 UTC  3 runs from 1, 2
+`},
+		{"Overlap_Top", []string{"overlap", "-tz", "UTC", "-from", "2024-06-15", "-top", "1", "CRON_TZ=UTC 0 */6 * * * /a", "CRON_TZ=UTC 0 */12 * * * /b"}, 0, `1  CRON_TZ=UTC 0 */6 * * * /a
+2  CRON_TZ=UTC 0 */12 * * * /b
+
+8 runs from Sat 2024-06-15 00:00:00 UTC to Sun 2024-06-16 00:00:00 UTC
+
+3 collisions
+Sat 2024-06-15 00:00:00 UTC  1, 2
+... and 2 more
+
+peak 2 jobs running at once, busiest minutes
+Sat 2024-06-15 00:00:00 UTC for 1m0s  1, 2
+
+busiest 1h0m0s windows
+Sat 2024-06-15 00:00:00 UTC  2 runs from 1, 2
+`},
+		{"Overlap_Negative_Duration", []string{"overlap", "-duration", "-5m", "0 0 * * * /a"}, 1, "error - -duration can't be negative\n"},
+		{"Overlap_Zero_Horizon", []string{"overlap", "-for", "0s", "0 0 * * * /a"}, 1, "error - -for must be more than 0\n"},
+		{"Overlap_Invalid", []string{"overlap", "0 0 * * * /a", "61 * * * * /b"}, 1, `error - parsing error - minute - invalid
+  61 * * * * /b
+  ^~
+  hint: minute must be 0-59
+`},
+		{"Export_Unknown_Format", []string{"export", "-format", "csv", "0 0 * * * /a"}, 1, "error - export - unknown format - csv\n"},
+		{"Export_To_Before_From", []string{"export", "-from", "2026-11-01", "-to", "2026-10-01", "0 0 * * * /a"}, 1, "error - -to is before -from\n"},
+		{"Convert_Spec", []string{"convert", "-dialect", "quartz", "0 */30 9-17 ? * MON-FRI"}, 0, "OnCalendar=Mon..Fri *-*-* 09..17:00,30:00\n"},
+		{"Convert_Reboot", []string{"convert", "@reboot /cmd"}, 1, "error - systemd - @reboot has no calendar time, use OnBootSec= instead\n"},
+		{"Convert_Cron", []string{"convert", "-to", "cron", "Mon..Fri *-*-* 09:00 Europe/London"}, 0, "CRON_TZ=Europe/London 0 9 * * MON-FRI\n"},
+		{"Convert_Cron_From_Cron", []string{"convert", "-to", "cron", "0 0 * * * x"}, 0, "0 0 * * *\n"},
+		{"Convert_Cron_Macro", []string{"convert", "-to", "cron", "@daily x"}, 0, "0 0 * * *\n"},
+		{"Convert_Cron_Seconds", []string{"convert", "-to", "cron", "*:*:30"}, 0, "30 * * * * *\nwarning - the expression has seconds, so it is read with -dialect seconds\n"},
+		{"Convert_Cron_Both_Days", []string{"convert", "-to", "cron", "Mon *-*-01,15"}, 1, "error - cron - days matching both day of month and day of week have no equivalent, as cron runs on days matching either\n"},
+		{"Convert_Cron_Invalid", []string{"convert", "-to", "cron", "Mon..Fri 25:00"}, 1, `error - parsing error - hour - invalid
+  Mon..Fri 25:00
+           ^~
+  hint: use 00-23, a range (ex 01..05) or a repetition (ex 00/15), separated by commas
+`},
+		{"Convert_AWS", []string{"convert", "-to", "aws", "0 9 * * 1-5 /usr/bin/report"}, 0, "cron(0 9 ? * 2-6 *)\nwarning - day of week numbers shift by one, as standard numbers Sunday 0 and aws numbers it 1, so day of week 1-5 is every day-of-week from Monday through Friday\n"},
+		{"Convert_AWS_Rate", []string{"convert", "-to", "aws", "@every 15m /usr/bin/poll"}, 0, "rate(15 minutes)\n"},
+		{"Convert_AWS_Step_Weekday", []string{"convert", "-to", "aws", "0 0 * * */2 x"}, 0, "cron(0 0 ? * */2 *)\n"},
+		{"Convert_AWS_Either_Day", []string{"convert", "-to", "aws", "0 0 1 * 1 /cmd"}, 1, "error - aws - days matching either day of month or day of week have no equivalent, as one of them must be ?\n"},
+		{"Convert_From_AWS_Year", []string{"convert", "-to", "cron", "cron(0 9 ? * 6#3 2030)"}, 0, "CRON_TZ=UTC 0 0 9 ? * 6#3 2030\nwarning - the expression has a year, which standard cron has no field for, so it is Quartz and read with -dialect quartz\n"},
+		{"Convert_From_AWS", []string{"convert", "-to", "cron", "cron(0 18 ? * 6L *)"}, 0, "CRON_TZ=UTC 0 18 * * 5L\nwarning - day of week numbers shift by one, as aws numbers Sunday 1 and standard numbers it 0, so day of week 6L is the last Friday of the month\n"},
+		{"Table_AWS", []string{"cron(0/30 9 ? * MON-FRI *)"}, 0, `minute        0 30
+hour          9
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   1 2 3 4 5
+time zone     UTC
+command       ` + "\n"},
+		{"Table_AWS_Invalid", []string{"cron(0 12 * * ?)"}, 1, `error - not enough parts in the cron expression
+  cron(0 12 * * ?)
+                 ^
+  hint: expected 6 fields: minute hour day-of-month month day-of-week year (ex cron(0 12 ? * MON-FRI *))
+`},
+		{"Convert_Unknown_Format", []string{"convert", "-to", "launchd", "0 0 * * * /a"}, 1, "error - convert - unknown format - launchd\n"},
+		{"Table_Systemd", []string{"-dialect", "systemd", "Sat,Sun 10:00"}, 0, `second        0
+minute        0
+hour          10
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 6
+command       ` + "\n"},
+		{"Unknown_View", []string{"-view", "grid", "0 9 * * * /cmd"}, 1, "error - view - unknown - grid\n"},
+		{"Lint", []string{"lint", "0 0 30 2 1 date +%F"}, 1, `error - unescaped-percent - the command has an unescaped %, which cron turns into a newline, escape it as \%
+warning - day-or - runs on days matching day of month 30 or day of week 1, not only days matching both
+`},
+		{"Lint_Clean", []string{"lint", "*/15 0 1,15 * * /usr/bin/find"}, 0, "no problems found\n"},
+		{"Lint_Info", []string{"lint", "* * * * * /cmd"}, 0, "info - every-minute - runs every minute\n"},
+		{"Lint_Fail_On", []string{"lint", "-fail-on", "info", "* * * * * /cmd"}, 1, "info - every-minute - runs every minute\n"},
+		{"Lint_Fail_On_Error", []string{"lint", "-fail-on", "error", "0 0 1 * 1 /cmd"}, 0, "warning - day-or - runs on days matching day of month 1 or day of week 1, not only days matching both\n"},
+		{"Lint_Disable", []string{"lint", "-disable", "day-or,unescaped-percent", "0 0 1 * 1 date +%F"}, 0, "no problems found\n"},
+		{"Lint_Day_Match_And", []string{"lint", "-day-match", "and", "0 0 30 2 1 /cmd"}, 1, "error - never-runs - never runs, no day in month 2 matches day of month 30 and day of week 1\n"},
+		{"Lint_Unknown_Rule", []string{"lint", "-disable", "day-and", "0 0 * * * /cmd"}, 1, "error - lint - unknown rule - day-and\n"},
+		{"Lint_Unknown_Severity", []string{"lint", "-fail-on", "fatal", "0 0 * * * /cmd"}, 1, "error - severity - unknown - fatal\n"},
+		{"Lint_Invalid", []string{"lint", "61 * * * * /cmd"}, 1, `error - parsing error - minute - invalid
+  61 * * * * /cmd
+  ^~
+  hint: minute must be 0-59
+`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var code int
+			out := CaptureOutput(func() {
+				code = run(tc.args)
+			})
+
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Streams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		out    string
+		logged string
+	}{
+		{"JSON", []string{"-output", "json", "* * * * * /cmd"}, "{\n", ""},
+		{"YAML", []string{"-output", "yaml", "* * * * * /cmd"}, "original: ", ""},
+		{"Table", []string{"* * * * * /cmd"}, "", "minute "},
+		{"Error", []string{"-output", "json", "61 * * * * /cmd"}, "", "error - "},
+		{"Convert_Units", []string{"convert", "0 2 * * * /cmd"}, "# cmd.timer\n", ""},
+		{"Convert_Spec", []string{"convert", "-dialect", "quartz", "0 0 2 * * ?"}, "OnCalendar=", ""},
+		{"Convert_Cron", []string{"convert", "-to", "cron", "Mon *-*-* 09:00"}, "0 9 * * MON\n", ""},
+		{"Convert_AWS", []string{"convert", "-to", "aws", "0 9 * * * /cmd"}, "cron(0 9 * * ? *)\n", ""},
+		{"Export", []string{"export", "-from", "2026-11-01", "-to", "2026-11-02", "0 9 * * * /cmd"}, "BEGIN:VCALENDAR\r\n", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, logged := CaptureStreams(func() {
+				run(tc.args)
+			})
+
+			assert.True(t, strings.HasPrefix(out, tc.out), out)
+			assert.True(t, strings.HasPrefix(logged, tc.logged), logged)
+			if tc.out != "" {
+				assert.Empty(t, logged)
+			} else {
+				assert.Empty(t, out)
+			}
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Crontab(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	testCases := []struct {
+		name     string
+		content  string
+		code     int
+		expected string
+	}{
+		{"Valid", "MAILTO=root\n# backup\n0 2 * * * /usr/bin/backup\n\n*/30 9 1 1 1 /usr/bin/report\n", 0, `# line 3
+# backup
+MAILTO=root
+minute        0
+hour          2
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+command       /usr/bin/backup
+
+# line 5
+MAILTO=root
+minute        0 30
+hour          9
+day of month  1
+month         1
+day of week   1
+days          day of month or day of week
+command       /usr/bin/report
+`},
+		{"Errors", "0 2 * * * /usr/bin/backup\n0 2 * *\n", 1, `# line 1
+minute        0
+hour          2
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+command       /usr/bin/backup
+error - line 2 - not enough parts in the cron expression
+  0 2 * *
+         ^
+  hint: expected 5 time fields followed by a command
+`},
+		{"Unrecognised", "1,q,r * * * * /usr/bin/backup\n", 1, `error - line 1 - parsing error - minute - unrecognised - q
+  1,q,r * * * * /usr/bin/backup
+    ^
+  hint: use 0-59, a range (ex 1-5) or a step (ex */15), separated by commas
+error - line 1 - parsing error - minute - unrecognised - r
+  1,q,r * * * * /usr/bin/backup
+      ^
+  hint: use 0-59, a range (ex 1-5) or a step (ex */15), separated by commas
+`},
+		{"Empty", "# nothing\n", 0, "no jobs found\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeFile(tc.name, tc.content)
+
+			var code int
+			out := CaptureOutput(func() {
+				code = run([]string{"-f", path})
+			})
+
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Missing file
+	t.Run("Missing", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"-f", filepath.Join(dir, "missing")})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, out, "error - crontab - open")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Lenient warns after the tables
+	t.Run("Lenient", func(t *testing.T) {
+		path := writeFile("lenient", "0 2 * * * /usr/bin/backup\n\n0 2,x * * * /usr/bin/report\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"-lenient", "-f", path})
+		})
+
+		assert.Equal(t, 0, code)
+		assert.True(t, strings.HasSuffix(out, `command       /usr/bin/report
+warning - line 3 - parsing error - hour - unrecognised - x
+  0 2,x * * * /usr/bin/report
+      ^
+  hint: use 0-23, a range (ex 1-5) or a step (ex */15), separated by commas
+`))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A calendar for each job, after its line and comments
+	t.Run("Calendar", func(t *testing.T) {
+		path := writeFile("calendar", "CRON_TZ=UTC\n# quarterly\n0 0 1 */3 * /usr/bin/report\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"-view", "calendar", "-year", "2024", "-f", path})
+		})
+
+		assert.Equal(t, 0, code)
+		assert.True(t, strings.HasPrefix(out, "# line 3\n# quarterly\nCRON_TZ=UTC\n           January 2024\n"))
+		assert.Equal(t, 12, strings.Count(out, " 2024\n"))
+		assert.Equal(t, 4, strings.Count(out, "1 day, 1 run\n"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// One heatmap of every job, followed by the lines with errors
+	t.Run("Heatmap", func(t *testing.T) {
+		path := writeFile("heatmap", "0 9 * * * /usr/bin/report\n0 2 * *\n*/20 9 * * * /usr/bin/poll\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"-view", "heatmap", "-bucket", "30", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.True(t, strings.HasPrefix(out, "    0\n00 │····│ 0\n"))
+		assert.Contains(t, out, "\n09 │██▒▒│ 4\n")
+		assert.Contains(t, out, `
+▒ 1  ▓ 2  █ 3 runs in 30 minutes
+1  0 9 * * * /usr/bin/report
+2  */20 9 * * * /usr/bin/poll
+busiest 09:00-09:29, 3 runs from 1, 2
+error - line 2 - not enough parts in the cron expression
+`)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Overlap every job, with comments setting durations
+	t.Run("Overlap", func(t *testing.T) {
+		path := writeFile("overlap", "CRON_TZ=UTC\n# visualcron:duration 20m\n0 0 * * * /usr/bin/backup\n0 2 * *\n*/15 * * * * /usr/bin/poll\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"overlap", "-tz", "UTC", "-from", "2024-06-15", "-for", "30m", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.True(t, strings.HasPrefix(out, "1  0 0 * * * /usr/bin/backup  (20m0s)\n2  */15 * * * * /usr/bin/poll\n"))
+		assert.Contains(t, out, `
+peak 2 jobs running at once, busiest minutes
+Sat 2024-06-15 00:00:00 UTC for 1m0s  1, 2
+Sat 2024-06-15 00:15:00 UTC for 1m0s  1, 2
+`)
+		assert.Contains(t, out, "\nerror - line 4 - not enough parts in the cron expression\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// An invalid duration comment
+	t.Run("Overlap_Invalid_Duration", func(t *testing.T) {
+		path := writeFile("overlap-duration", "# visualcron:duration forever\n0 0 * * * /usr/bin/backup\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"overlap", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "error - line 2 - overlap - invalid duration - forever\n", out)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Lint every job, with comments disabling rules
+	t.Run("Lint", func(t *testing.T) {
+		path := writeFile("lint", `# visualcron:disable day-or
+0 0 1 * 1 /usr/bin/report
+
+0 0 1 * 1 /usr/bin/backup
+0 2 * *
+* * * * * /usr/bin/poll
+# visualcron:disable every-minit
+* * * * * /usr/bin/watch
+`)
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"lint", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, `warning - line 4 - day-or - runs on days matching day of month 1 or day of week 1, not only days matching both
+info - line 6 - every-minute - runs every minute
+error - line 8 - unknown-rule - visualcron:disable names unknown rule every-minit
+info - line 8 - every-minute - runs every minute
+error - line 5 - not enough parts in the cron expression
+  0 2 * *
+         ^
+  hint: expected 5 time fields followed by a command
+`, out)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// JSON includes the errors and still fails
+	t.Run("JSON_Errors", func(t *testing.T) {
+		path := writeFile("json", "@reboot /usr/bin/start\n0 2 * *\n")
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"-output", "json", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, `{
+  "entries": [
+    {
+      "line": 1,
+      "cron": {
+        "original": "@reboot /usr/bin/start",
+        "kind": "reboot",
+        "command": "/usr/bin/start"
+      }
+    }
+  ],
+  "errors": [
+    "line 2 - not enough parts in the cron expression"
+  ]
+}
+`, out)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Export(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A recurring event and an event for every run
+	t.Run("ICS", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"export", "-tz", "UTC", "-from", "2026-11-01", "-to", "2026-12-01", "CRON_TZ=UTC 0 9 * * 1-5 /usr/bin/report", "CRON_TZ=UTC 0 0 1 * 1 /usr/bin/backup"})
+		})
+
+		assert.Equal(t, 0, code)
+		assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+		assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+		assert.Contains(t, out, "\r\nDTSTART:20261102T090000Z\r\nRRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0;UNTIL=\r\n 20261130T090000Z\r\nSUMMARY:/usr/bin/report\r\n")
+		assert.Equal(t, 7, strings.Count(out, "SUMMARY:/usr/bin/backup\r\n"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Every job in a crontab, followed by the lines with errors
+	t.Run("Crontab", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "crontab")
+		assert.Nil(t, os.WriteFile(path, []byte("CRON_TZ=UTC\n0 9 * * * /usr/bin/report\n0 2 * *\n"), 0644))
+
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"export", "-expand", "-tz", "UTC", "-from", "2026-11-01", "-to", "2026-11-03", "-f", path})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, 2, strings.Count(out, "SUMMARY:/usr/bin/report\r\n"))
+		assert.Contains(t, out, "END:VCALENDAR\r\nerror - line 3 - not enough parts in the cron expression\n")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Convert(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A timer and service named after the command
+	t.Run("Units", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"convert", "0 0 1,15 * 5 /usr/local/bin/backup.sh --full"})
+		})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, `# backup.timer
+[Unit]
+Description=Run backup.service on the schedule 0 0 1,15 * 5
+
+[Timer]
+OnCalendar=*-*-01,15 00:00:00
+OnCalendar=Fri *-*-* 00:00:00
+# Start on time, as cron does, instead of up to a minute late
+AccuracySec=1s
+
+[Install]
+WantedBy=timers.target
+
+# backup.service
+[Unit]
+Description=/usr/local/bin/backup.sh --full
+
+[Service]
+Type=oneshot
+ExecStart=/bin/sh -c "/usr/local/bin/backup.sh --full"
+`, out)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Named units
+	t.Run("Name", func(t *testing.T) {
+		out := CaptureOutput(func() {
+			run([]string{"convert", "-name", "nightly", "0 2 * * * /cmd"})
+		})
+
+		assert.Contains(t, out, "# nightly.timer\n[Unit]\nDescription=Run nightly.service on the schedule 0 2 * * *\n")
+		assert.Contains(t, out, "# nightly.service\n")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_UnitName(t *testing.T) {
+	assert.Equal(t, "backup", unitName("/usr/local/bin/backup.sh --full"))
+	assert.Equal(t, "run-parts", unitName("run-parts /etc/cron.daily"))
+	assert.Equal(t, "visualcron", unitName("[ -x /x ] && /x"))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_K8s(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	writeFile("jobs/backup.yaml", "---\n# Source: jobs/templates/backup.yaml\nkind: CronJob\nmetadata:\n  name: backup\n  namespace: ops\nspec:\n  schedule: \"0 3 * * *\"\n  timeZone: UTC\n  concurrencyPolicy: Forbid\n---\nkind: CronJob\nmetadata:\n  name: broken\nspec:\n  schedule: \"0 3 * *\"\n")
+	writeFile("jobs/report.yml", "kind: CronJob\nmetadata:\n  name: report\nspec:\n  schedule: \"@weekly\"\n  suspend: true\n")
+	writeFile("jobs/config.yaml", "kind: ConfigMap\nmetadata:\n  name: config\n")
+	writeFile("jobs/README.md", "kind: CronJob\n")
+	writeFile(".git/cronjob.yaml", "kind: CronJob\n")
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Every CronJob under the directory, followed by the errors
+	t.Run("Directory", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"k8s", "-f", dir})
+		})
+
+		backup := filepath.Join(dir, "jobs", "backup.yaml")
+		report := filepath.Join(dir, "jobs", "report.yml")
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "# "+backup+`
+# line 8
+# cronjob ops/backup
+# concurrency Forbid
+minute        0
+hour          3
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+time zone     UTC
+command       backup
+
+# `+report+`
+# line 5
+# cronjob report
+# suspended
+# concurrency Allow
+minute        0
+hour          0
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0
+command       report
+error - `+backup+` - line 16 - cronjob broken - not enough parts in the cron expression
+  0 3 * *
+         ^
+  hint: expected 5 fields: minute hour day-of-month month day-of-week (ex 0 3 * * *)
+`, out)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// JSON keeps the file of each CronJob
+	t.Run("JSON", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"k8s", "-output", "json", "-f", filepath.Join(dir, "jobs", "report.yml")})
+		})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, out, `"file": "`+filepath.Join(dir, "jobs", "report.yml")+`"`)
+		assert.Contains(t, out, `"name": "report",`)
+		assert.Contains(t, out, `"suspend": true,`)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Errors
+	t.Run("Errors", func(t *testing.T) {
+		out := CaptureOutput(func() {
+			assert.Equal(t, 1, run([]string{"k8s"}))
+		})
+		assert.Equal(t, "error - k8s - -f is required\n", out)
+
+		out = CaptureOutput(func() {
+			assert.Equal(t, 1, run([]string{"k8s", "-f", filepath.Join(dir, "missing")}))
+		})
+		assert.True(t, strings.HasPrefix(out, "error - k8s - stat "))
+
+		out = CaptureOutput(func() {
+			assert.Equal(t, 0, run([]string{"k8s", "-f", filepath.Join(dir, "jobs", "config.yaml")}))
+		})
+		assert.Equal(t, "no cronjobs found\n", out)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_CI(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	writeFile(".github/workflows/nightly.yml", "name: Nightly\non:\n  schedule:\n    - cron: \"30 2 * * *\"\n    - cron: \"*/2 * * * *\"\n")
+	writeFile(".github/workflows/ci.yml", "on: [push]\n")
+	writeFile(".github/dependabot.yml", "on:\n  schedule:\n    - cron: \"0 0 * * *\"\n")
+	writeFile("schedules.json", `[{"description": "Report", "cron": "0 9 * * 1", "cron_timezone": "Europe/London"}, {"description": "Bad", "cron": "0 9 * *"}]`)
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// The workflows under a directory, followed by the findings
+	t.Run("GitHub", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"ci", "-f", dir})
+		})
+
+		nightly := filepath.Join(dir, ".github", "workflows", "nightly.yml")
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "# "+nightly+`
+# line 4
+# github Nightly
+minute        30
+hour          2
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+time zone     UTC
+command       Nightly
+
+# `+nightly+`
+# line 5
+# github Nightly
+minute        0 2 4 6 8 10 12 14 16 18 20 22 24 26 28 30 32 34 36 38 40 42 44 46 48 50 52 54 56 58
+hour          0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23
+day of month  1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31
+month         1 2 3 4 5 6 7 8 9 10 11 12
+day of week   0 1 2 3 4 5 6
+time zone     UTC
+command       Nightly
+warning - `+nightly+` - line 5 - github-interval - runs 2m0s apart, but GitHub runs a schedule at most every 5 minutes
+`, out)
+
+		// Warnings only fail at -fail-on warning
+		CaptureOutput(func() {
+			code = run([]string{"ci", "-fail-on", "error", "-f", dir})
+		})
+		assert.Equal(t, 0, code)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A GitLab export, followed by the errors
+	t.Run("GitLab", func(t *testing.T) {
+		var code int
+		out := CaptureOutput(func() {
+			code = run([]string{"ci", "-f", filepath.Join(dir, "schedules.json")})
+		})
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, out, "# line 1\n# gitlab Report\n")
+		assert.Contains(t, out, "time zone     Europe/London\n")
+		assert.Contains(t, out, "error - "+filepath.Join(dir, "schedules.json")+" - line 1 - gitlab Bad - not enough parts in the cron expression\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Errors
+	t.Run("Errors", func(t *testing.T) {
+		out := CaptureOutput(func() {
+			assert.Equal(t, 1, run([]string{"ci"}))
+		})
+		assert.Equal(t, "error - ci - -f is required\n", out)
+
+		out = CaptureOutput(func() {
+			assert.Equal(t, 0, run([]string{"ci", "-f", filepath.Join(dir, ".github", "workflows", "ci.yml")}))
+		})
+		assert.Equal(t, "no schedules found\n", out)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_IsSystemCrontab(t *testing.T) {
+	assert.True(t, isSystemCrontab("/etc/crontab"))
+	assert.True(t, isSystemCrontab("/etc/cron.d/backup"))
+	assert.True(t, isSystemCrontab("/etc/cron.d/../cron.d/backup"))
+	assert.False(t, isSystemCrontab("/var/spool/cron/crontabs/root"))
+	assert.False(t, isSystemCrontab("crontab"))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Commands_Caret(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      cron.ParseError
+		expected string
+	}{
+		{"Start", cron.ParseError{Expression: "61 * * * * /cmd", Column: 0, Token: "61"}, "^~"},
+		{"Tabs", cron.ParseError{Expression: "0\t0\t61 * * /cmd", Column: 4, Token: "61"}, " \t \t^~"},
+		{"End", cron.ParseError{Expression: "0 2 * *", Column: 7}, "       ^"},
+		{"Past_End", cron.ParseError{Expression: "0 2", Column: 10}, "   ^"},
+		{"Unicode", cron.ParseError{Expression: "é 0 * * * /cmd", Column: 3, Token: "0"}, "  ^"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, caret(&tc.err))
+		})
+	}
+}
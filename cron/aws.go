@@ -0,0 +1,373 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// AWS EventBridge format
+//
+//	cron(minute hour day-of-month month day-of-week year)
+//	rate(value unit)
+//
+//	cron(0 12 ? * MON-FRI *)
+//	rate(5 minutes)
+//
+// The cron() fields are Quartz's without seconds. Days of the week are
+// 1 - 7 from Sunday, one of the day fields must be ? and the year is
+// required. The rate() unit is minute, hour or day, plural unless the
+// value is 1. Schedules run in UTC
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// An EventBridge schedule starts with cron( or rate(
+var awsRegex = regexp.MustCompile(`^\s*(cron|rate)\(`)
+
+// A start/step item (ex 0/15)
+var awsStepRegex = regexp.MustCompile(`^(\d+)/(\d+)$`)
+
+// awsUnits are the rate() units and their lengths
+var awsUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// awsMaxima are the last values of the cron() fields
+var awsMaxima = []int{59, 23, 31, 12, 7, 2099}
+
+// Hints for EventBridge schedules
+const (
+	awsFormat   = "use cron(minute hour day-of-month month day-of-week year) or rate(value unit) (ex cron(0 12 ? * MON-FRI *))"
+	awsCronHint = "expected 6 fields: minute hour day-of-month month day-of-week year (ex cron(0 12 ? * MON-FRI *))"
+	awsRateHint = "use a whole number and minute, hour or day, plural unless the number is 1 (ex rate(5 minutes) or rate(1 hour))"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseAWS parses an EventBridge cron() or rate() schedule
+//
+// See the AWS EventBridge format above
+func parseAWS(exp string, opts ParseOptions) (*Cron, error) {
+	match := awsRegex.FindStringSubmatch(exp)
+	if match == nil {
+		offsets := fieldOffsets(exp)
+		if len(offsets) == 0 {
+			return nil, missingError(exp, awsFormat)
+		}
+		return nil, &ParseError{
+			Expression: exp,
+			Field:      "aws",
+			Column:     offsets[0],
+			Token:      strings.Fields(exp)[0],
+			Hint:       awsFormat,
+			Err:        fmt.Errorf("expected cron() or rate()"),
+		}
+	}
+
+	// The fields between the brackets
+	start := len(match[0])
+	end := len(strings.TrimRightFunc(exp, unicode.IsSpace))
+	if !strings.HasSuffix(exp[:end], ")") {
+		return nil, &ParseError{
+			Expression: exp,
+			Field:      "aws",
+			Column:     end,
+			Hint:       "close the schedule with ) (ex cron(0 12 * * ? *))",
+			Err:        fmt.Errorf("missing )"),
+		}
+	}
+	inner := exp[start : end-1]
+
+	if match[1] == "rate" {
+		return parseRate(exp, inner, start)
+	}
+
+	parts := strings.Fields(inner)
+	offsets := fieldOffsets(inner)
+	switch {
+	case len(parts) < 6:
+		return nil, &ParseError{
+			Expression: exp,
+			Column:     end - 1,
+			Hint:       awsCronHint,
+			Err:        errNotEnoughParts,
+		}
+	case len(parts) > 6:
+		return nil, &ParseError{
+			Expression: exp,
+			Field:      "aws",
+			Column:     start + offsets[6],
+			Token:      parts[6],
+			Hint:       awsCronHint,
+			Err:        fmt.Errorf("too many fields"),
+		}
+	case !yearRegex.MatchString(parts[5]):
+		return nil, &ParseError{
+			Expression: exp,
+			Field:      "year",
+			Column:     start + offsets[5],
+			Token:      parts[5],
+			Hint:       "year must be " + fieldValues["year"],
+			Err:        errInvalid,
+		}
+	}
+
+	// The fields are Quartz's after a second. Quartz's start/step (ex
+	// 0/15) steps to the last value, so it is parsed as a range (ex
+	// 0-59/15)
+	fields := make([]string, len(parts))
+	for i, part := range parts {
+		fields[i] = awsSteps(part, awsMaxima[i])
+	}
+	quartz := "0 " + strings.Join(fields, " ")
+	quartzOffsets := fieldOffsets(quartz)[1:]
+
+	quartzOpts := opts
+	quartzOpts.Dialect = DialectQuartz
+	quartzOpts.System = false
+	c, err := ParseWithOptions(quartz, quartzOpts)
+
+	// rebase moves an error back to the field it is in, pointing at the
+	// whole field when it was rewritten
+	rebase := func(pe *ParseError) {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if pe.Column < quartzOffsets[i] {
+				continue
+			}
+
+			if fields[i] != parts[i] {
+				pe.Column, pe.Token = start+offsets[i], parts[i]
+			} else {
+				pe.Column = start + offsets[i] + pe.Column - quartzOffsets[i]
+			}
+			break
+		}
+
+		pe.Expression = exp
+		if pe.Field == "quartz" {
+			pe.Field = "aws"
+			pe.Hint = "use ? in one of day of month or day of week (ex cron(0 12 ? * MON *))"
+		}
+	}
+
+	if err != nil {
+		var errs ParseErrors
+		var pe *ParseError
+		if errors.As(err, &errs) {
+			for _, pe := range errs {
+				rebase(pe)
+			}
+		} else if errors.As(err, &pe) {
+			rebase(pe)
+		}
+		return nil, err
+	}
+
+	for _, pe := range c.Warnings {
+		rebase(pe)
+	}
+
+	c.Original = exp
+	c.Source.Dialect = DialectAWS
+	c.Source.Second = ""
+	c.Second = nil
+	c.Location = time.UTC
+
+	return c, nil
+}
+
+// awsSteps rewrites each start/step item of a field as a range to last
+// (ex 5/15 to 5-59/15)
+func awsSteps(field string, last int) string {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		if match := awsStepRegex.FindStringSubmatch(item); match != nil {
+			items[i] = fmt.Sprintf("%s-%d/%s", match[1], last, match[2])
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseRate parses the value and unit of a rate() schedule, which
+// start at column in exp
+func parseRate(exp, inner string, column int) (*Cron, error) {
+	parts := strings.Fields(inner)
+	offsets := fieldOffsets(inner)
+
+	fail := func(n int) (*Cron, error) {
+		pe := &ParseError{
+			Expression: exp,
+			Field:      "rate",
+			Column:     column,
+			Hint:       awsRateHint,
+			Err:        errInvalid,
+		}
+		if n < len(parts) {
+			pe.Column += offsets[n]
+			pe.Token = parts[n]
+		} else {
+			pe.Column += len(inner)
+			pe.Err = errNotEnoughParts
+		}
+		return nil, pe
+	}
+
+	if len(parts) == 0 {
+		return fail(0)
+	}
+
+	value, err := strconv.Atoi(parts[0])
+	if err != nil || value < 1 || parts[0][0] == '+' {
+		return fail(0)
+	}
+
+	if len(parts) < 2 {
+		return fail(1)
+	}
+	if len(parts) > 2 {
+		return fail(2)
+	}
+
+	// The unit is singular for 1 and plural otherwise
+	unit := parts[1]
+	if value != 1 {
+		if !strings.HasSuffix(unit, "s") {
+			return fail(1)
+		}
+		unit = strings.TrimSuffix(unit, "s")
+	}
+	length, ok := awsUnits[unit]
+	if !ok {
+		return fail(1)
+	}
+
+	return &Cron{
+		Original: exp,
+		Source:   Source{Dialect: DialectAWS},
+		Kind:     KindInterval,
+		Interval: time.Duration(value) * length,
+	}, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// AWSExpression returns the schedule as an EventBridge cron() or
+// rate() schedule (ex cron(0 12 ? * 2-6 *))
+//
+// Days of the week are written as EventBridge numbers them, 1 - 7 from
+// Sunday (see WeekdayShift). Schedules EventBridge can't express return
+// an error saying why: @reboot, seconds, time zones other than UTC and
+// days that can match either day field, as one of them must be ?
+func (c Cron) AWSExpression() (string, error) {
+	switch c.Kind {
+	case KindReboot:
+		return "", fmt.Errorf("aws - @reboot has no EventBridge equivalent, as schedules don't start with a daemon")
+	case KindInterval:
+		return awsRate(c.Interval)
+	}
+
+	if c.Location != nil && c.Location.String() != "UTC" {
+		return "", fmt.Errorf("aws - EventBridge schedules run in UTC, so time zone %s has no equivalent", c.Location)
+	}
+
+	if seconds, _ := c.seconds(); len(seconds) != 1 || seconds[0] != 0 {
+		return "", fmt.Errorf("aws - EventBridge has no seconds field, so second %s has no equivalent", c.Source.Second)
+	}
+
+	if dom, dow := c.dayFields(); dom && dow && c.Days.Either() {
+		return "", fmt.Errorf("aws - days matching either day of month or day of week have no equivalent, as one of them must be ?")
+	}
+
+	dom, dow, ok := c.cronDays(1, nil)
+	if !ok {
+		return "", fmt.Errorf("aws - days matching both day of month and day of week have no equivalent, as one of them must be ?")
+	}
+	switch {
+	case dom != "*" && dow != "*":
+		return "", fmt.Errorf("aws - days limited by both day of month and day of week have no equivalent, as one of them must be ?")
+	case dow != "*":
+		dom = "?"
+	default:
+		dow = "?"
+	}
+
+	year := "*"
+	if len(c.Year) > 0 {
+		year = cronList(c.Year, defaultYearSlice, nil)
+	}
+
+	fields := []string{
+		cronList(c.Minute, defaultMinuteSlice, nil),
+		cronList(c.Hour, defaultHourSlice, nil),
+		dom,
+		cronList(c.Month, defaultMonthSlice, nil),
+		dow,
+		year,
+	}
+
+	return "cron(" + strings.Join(fields, " ") + ")", nil
+}
+
+// awsRate returns an interval as a rate() schedule, in the largest
+// unit it is a whole number of
+func awsRate(interval time.Duration) (string, error) {
+	for _, unit := range []string{"day", "hour", "minute"} {
+		length := awsUnits[unit]
+		if interval%length != 0 {
+			continue
+		}
+
+		value := int(interval / length)
+		if value != 1 {
+			unit += "s"
+		}
+		return fmt.Sprintf("rate(%d %s)", value, unit), nil
+	}
+
+	return "", fmt.Errorf("aws - rate() is a whole number of minutes, so @every %s has no equivalent", interval)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// WeekdayShift returns a warning when the day of week numbers the
+// expression was written with stand for other days in the to dialect,
+// or "" when they don't. Quartz and EventBridge number Sunday 1 where
+// the other dialects number it 0, so 1-5 is Monday - Friday in cron
+// but Sunday - Thursday in EventBridge
+func (c Cron) WeekdayShift(to Dialect) string {
+	from := c.Source.Dialect
+	if from.quartzWeekdays() == to.quartzWeekdays() {
+		return ""
+	}
+
+	// Steps from * (ex */2) are the same days in both
+	numbered := false
+	for _, item := range strings.Split(c.Source.DayOfWeek, ",") {
+		if !strings.HasPrefix(item, "*") && strings.ContainsAny(item, "0123456789") {
+			numbered = true
+		}
+	}
+	if !numbered {
+		return ""
+	}
+
+	sunday := func(d Dialect) int {
+		if d.quartzWeekdays() {
+			return 1
+		}
+		return 0
+	}
+
+	return fmt.Sprintf("day of week numbers shift by one, as %s numbers Sunday %d and %s numbers it %d, so day of week %s is %s",
+		from, sunday(from), to, sunday(to), c.Source.DayOfWeek, describeSegment(c.Source.DayOfWeek, dowUnit(from.quartzWeekdays())))
+}
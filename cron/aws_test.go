@@ -0,0 +1,220 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_AWS_Parse(t *testing.T) {
+	aws := ParseOptions{Dialect: DialectAWS}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		exp      string
+		expected Source
+	}{
+		{"Daily", "cron(0 12 * * ? *)", Source{DialectAWS, "", "0", "12", "*", "*", "?", "*"}},
+		{"Weekdays", "cron(0 9 ? * 2-6 *)", Source{DialectAWS, "", "0", "9", "?", "*", "2-6", "*"}},
+		{"Start_Step", "cron(0/15 9-17 ? * MON-FRI *)", Source{DialectAWS, "", "0-59/15", "9-17", "?", "*", "MON-FRI", "*"}},
+		{"Rules", "cron(0 18 ? * 6L 2027)", Source{DialectAWS, "", "0", "18", "?", "*", "6L", "2027"}},
+		{"Spaces", "  cron( 0  12 L * ? * )  ", Source{DialectAWS, "", "0", "12", "L", "*", "?", "*"}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.exp, aws)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.Source)
+			assert.Equal(t, tc.exp, c.Original)
+			assert.Equal(t, time.UTC, c.Location)
+			assert.Nil(t, c.Second)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Days of the week are 1 - 7 from Sunday
+	t.Run("Days", func(t *testing.T) {
+		c, err := Parse("cron(0 9 ? * 2-6 *)")
+		assert.Nil(t, err)
+		assert.Equal(t, IntSlice{1, 2, 3, 4, 5}, c.DayOfWeek)
+		assert.Equal(t, "At 09:00 on every day-of-week from Monday through Friday (UTC).", c.Describe())
+
+		from := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC), c.Next(from))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A time zone, as EventBridge Scheduler has
+	t.Run("Time_Zone", func(t *testing.T) {
+		c, err := Parse("CRON_TZ=Europe/London cron(0 9 ? * * *)")
+		assert.Nil(t, err)
+		assert.Equal(t, "Europe/London", c.Location.String())
+		assert.Equal(t, DialectAWS, c.Source.Dialect)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Rates
+	rateTestCases := []struct {
+		exp      string
+		expected time.Duration
+	}{
+		{"rate(1 minute)", time.Minute},
+		{"rate(5 minutes)", 5 * time.Minute},
+		{"rate(1 hour)", time.Hour},
+		{"rate(12 hours)", 12 * time.Hour},
+		{"rate(7 days)", 7 * 24 * time.Hour},
+	}
+
+	for _, tc := range rateTestCases {
+		t.Run(tc.exp, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+			assert.Equal(t, KindInterval, c.Kind)
+			assert.Equal(t, tc.expected, c.Interval)
+			assert.Equal(t, DialectAWS, c.Source.Dialect)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	invalidTestCases := []struct {
+		name string
+		exp  string
+		err  *ParseError
+	}{
+		{"Not_AWS", "0 12 * * ? *", &ParseError{"0 12 * * ? *", "aws", 0, "0", awsFormat, nil}},
+		{"Missing_Bracket", "cron(0 12 * * ? *", &ParseError{"cron(0 12 * * ? *", "aws", 17, "", "close the schedule with ) (ex cron(0 12 * * ? *))", nil}},
+		{"No_Year", "cron(0 12 * * ?)", &ParseError{"cron(0 12 * * ?)", "", 15, "", awsCronHint, errNotEnoughParts}},
+		{"Too_Many", "cron(0 0 12 * * ? *)", &ParseError{"cron(0 0 12 * * ? *)", "aws", 18, "*", awsCronHint, nil}},
+		{"Year", "cron(0 12 * * ? 20x)", &ParseError{"cron(0 12 * * ? 20x)", "year", 16, "20x", "year must be 1970-2099", errInvalid}},
+		{"Question_Mark", "cron(0 12 * * * *)", &ParseError{"cron(0 12 * * * *)", "aws", 10, "*", "use ? in one of day of month or day of week (ex cron(0 12 ? * MON *))", nil}},
+		{"Hour", "cron(0  25 * * ? *)", &ParseError{"cron(0  25 * * ? *)", "hour", 8, "25", "hour must be 0-23", nil}},
+		{"Start_Step", "cron(70/15 * * * ? *)", &ParseError{"cron(70/15 * * * ? *)", "minute", 5, "70/15", "a range must go from low to high, within 0-59", nil}},
+		{"Rate_Singular", "rate(1 minutes)", &ParseError{"rate(1 minutes)", "rate", 7, "minutes", awsRateHint, errInvalid}},
+		{"Rate_Plural", "rate(5 minute)", &ParseError{"rate(5 minute)", "rate", 7, "minute", awsRateHint, errInvalid}},
+		{"Rate_Zero", "rate(0 days)", &ParseError{"rate(0 days)", "rate", 5, "0", awsRateHint, errInvalid}},
+		{"Rate_Unit", "rate(2 weeks)", &ParseError{"rate(2 weeks)", "rate", 7, "weeks", awsRateHint, errInvalid}},
+		{"Rate_No_Unit", "rate(5)", &ParseError{"rate(5)", "rate", 6, "", awsRateHint, errNotEnoughParts}},
+	}
+
+	for _, tc := range invalidTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseWithOptions(tc.exp, aws)
+
+			pe, ok := err.(*ParseError)
+			assert.True(t, ok)
+			if tc.err.Err == nil {
+				tc.err.Err = pe.Err
+			}
+			assert.Equal(t, tc.err, pe)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_AWS_AWSExpression(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		exp      string
+		expected string
+	}{
+		{"Weekdays", "0 9 * * 1-5 /cmd", "cron(0 9 ? * 2-6 *)"},
+		{"Sunday", "0 9 * * 0,7 /cmd", "cron(0 9 ? * 1 *)"},
+		{"Every", "*/5 * * * * /cmd", "cron(*/5 * * * ? *)"},
+		{"Day_Of_Month", "0 0 1,15 * * /cmd", "cron(0 0 1,15 * ? *)"},
+		{"Rules", "0 0 12 ? * 6L 2030", "cron(0 12 ? * 6L 2030)"},
+		{"Nth_Day", "0 0 12 ? * MON#2", "cron(0 12 ? * 2#2 *)"},
+		{"UTC", "CRON_TZ=UTC 30 6 L * * /cmd", "cron(30 6 L * ? *)"},
+		{"Round_Trip", "cron(0/15 9-17 ? * MON-FRI *)", "cron(*/15 9-17 ? * 2-6 *)"},
+		{"Rate_Minutes", "@every 90m /cmd", "rate(90 minutes)"},
+		{"Rate_Hour", "@every 1h /cmd", "rate(1 hour)"},
+		{"Rate_Days", "@every 48h /cmd", "rate(2 days)"},
+		{"Step_Weekday", "0 0 * * */2 /cmd", "cron(0 0 ? * */2 *)"},
+		{"Step_Day", "0 0 */2 * * /cmd", "cron(0 0 */2 * ? *)"},
+		{"Whole_Day_Either", "0 0 1-31 * MON /cmd", "cron(0 0 * * ? *)"},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			res, err := c.AWSExpression()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A weekday in one week of the month from systemd
+	t.Run("Systemd", func(t *testing.T) {
+		c, err := ParseWithOptions("Mon *-*-01..07 09:00", ParseOptions{Dialect: DialectSystemd})
+		assert.Nil(t, err)
+
+		res, err := c.AWSExpression()
+		assert.Nil(t, err)
+		assert.Equal(t, "cron(0 9 ? * 2#1 *)", res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Schedules without an equivalent
+	invalidTestCases := []struct {
+		name string
+		exp  string
+		opts ParseOptions
+		err  string
+	}{
+		{"Reboot", "@reboot /cmd", ParseOptions{}, "aws - @reboot has no EventBridge equivalent, as schedules don't start with a daemon"},
+		{"Every_Seconds", "@every 90s /cmd", ParseOptions{}, "aws - rate() is a whole number of minutes, so @every 1m30s has no equivalent"},
+		{"Time_Zone", "CRON_TZ=Europe/London 0 9 * * * /cmd", ParseOptions{}, "aws - EventBridge schedules run in UTC, so time zone Europe/London has no equivalent"},
+		{"Seconds", "30 0 9 * * ?", ParseOptions{}, "aws - EventBridge has no seconds field, so second 30 has no equivalent"},
+		{"Either_Day", "0 0 1 * 1 /cmd", ParseOptions{}, "aws - days matching either day of month or day of week have no equivalent, as one of them must be ?"},
+		{"Both_Days", "0 0 1 * 1 /cmd", ParseOptions{DayMatch: DayMatchAnd}, "aws - days matching both day of month and day of week have no equivalent, as one of them must be ?"},
+		{"Step_Day_Weekday", "0 0 */2 * MON /cmd", ParseOptions{}, "aws - days limited by both day of month and day of week have no equivalent, as one of them must be ?"},
+	}
+
+	for _, tc := range invalidTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.exp, tc.opts)
+			assert.Nil(t, err)
+
+			_, err = c.AWSExpression()
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_AWS_WeekdayShift(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		to       Dialect
+		expected string
+	}{
+		{"To_AWS", "0 9 * * 1-5 /cmd", DialectAWS, "day of week numbers shift by one, as standard numbers Sunday 0 and aws numbers it 1, so day of week 1-5 is every day-of-week from Monday through Friday"},
+		{"From_AWS", "cron(0 18 ? * 6L *)", DialectStandard, "day of week numbers shift by one, as aws numbers Sunday 1 and standard numbers it 0, so day of week 6L is the last Friday of the month"},
+		{"Names", "0 9 * * MON-FRI /cmd", DialectAWS, ""},
+		{"Any_Day", "0 9 * * * /cmd", DialectAWS, ""},
+		{"Same_Numbers", "0 0 12 ? * 2-6", DialectAWS, ""},
+		{"Step", "0 0 * * */2 /cmd", DialectAWS, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.WeekdayShift(tc.to))
+		})
+	}
+}
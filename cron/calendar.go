@@ -0,0 +1,165 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// calendarWidth is the width of a day in the calendar grid
+const calendarWidth = 5
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DayCounts returns how many times the schedule fires on each day of
+// a month, in the schedule's Location or local time. The first count
+// is the 1st of the month
+//
+// Only time schedules are counted, as @reboot and @every have no
+// fixed run times
+func (c Cron) DayCounts(year int, month time.Month) []int {
+	loc := c.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	counts := make([]int, daysInMonth(year, month))
+	if c.Kind != KindTime {
+		return counts
+	}
+
+	seconds, _ := c.seconds()
+	perDay := len(c.Hour) * len(c.Minute) * len(seconds)
+
+	for i := range counts {
+		start := time.Date(year, month, i+1, 0, 0, 0, 0, loc)
+		if len(c.Year) > 0 && !c.Year.Contains(year) || !c.Month.Contains(int(month)) || !c.matchesDay(start) {
+			continue
+		}
+
+		// Daylight saving time only changes the runs on days that are
+		// not 24 hours long
+		end := time.Date(year, month, i+2, 0, 0, 0, 0, loc)
+		if end.Sub(start) == 24*time.Hour {
+			counts[i] = perDay
+			continue
+		}
+
+		for _, o := range c.OccurrencesBetween(start, end.Add(-time.Nanosecond)) {
+			if o.Runs() {
+				counts[i]++
+			}
+		}
+	}
+
+	return counts
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Calendar returns a month grid, in the style of cal, with the days
+// the schedule fires in brackets and the number of runs below them,
+// followed by the totals for the month
+//
+//	       February 2024
+//	Su   Mo   Tu   We   Th   Fr   Sa
+//	                   [1]    2    3
+//	                     2
+func (c Cron) Calendar(year int, month time.Month) string {
+	counts := c.DayCounts(year, month)
+
+	var sb strings.Builder
+
+	title := fmt.Sprintf("%s %d", month, year)
+	width := 7 * calendarWidth
+	sb.WriteString(strings.Repeat(" ", (width-len(title))/2) + title + "\n")
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		fmt.Fprintf(&sb, "%*s", calendarWidth, d.String()[:2])
+	}
+	sb.WriteString("\n")
+
+	// Each week is a row of days followed by a row of counts
+	first := int(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday())
+	for week := 0; week*7-first < len(counts); week++ {
+		var days, runs strings.Builder
+		for d := 0; d < 7; d++ {
+			i := week*7 + d - first
+			if i < 0 || i >= len(counts) {
+				days.WriteString(strings.Repeat(" ", calendarWidth))
+				runs.WriteString(strings.Repeat(" ", calendarWidth))
+				continue
+			}
+
+			if counts[i] == 0 {
+				fmt.Fprintf(&days, "%*d", calendarWidth, i+1)
+				runs.WriteString(strings.Repeat(" ", calendarWidth))
+				continue
+			}
+
+			fmt.Fprintf(&days, "%*s", calendarWidth, "["+strconv.Itoa(i+1)+"]")
+			fmt.Fprintf(&runs, "%*s ", calendarWidth-1, runCount(counts[i]))
+		}
+
+		sb.WriteString(strings.TrimRight(days.String(), " ") + "\n")
+		if line := strings.TrimRight(runs.String(), " "); line != "" {
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	sb.WriteString(calendarSummary(counts) + "\n")
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CalendarYear returns the Calendar of every month of a year
+func (c Cron) CalendarYear(year int) string {
+	months := make([]string, 12)
+	for i := range months {
+		months[i] = c.Calendar(year, time.Month(i+1))
+	}
+	return strings.Join(months, "\n")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runCount returns a number of runs short enough to fit under a day
+// (ex 1440, or 86k for 86400)
+func runCount(n int) string {
+	if n < 10000 {
+		return strconv.Itoa(n)
+	}
+	return strconv.Itoa(n/1000) + "k"
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// calendarSummary totals the days and runs of a month
+func calendarSummary(counts []int) string {
+	days, runs := 0, 0
+	for _, n := range counts {
+		if n > 0 {
+			days++
+			runs += n
+		}
+	}
+
+	if days == 0 {
+		return "no runs"
+	}
+
+	return fmt.Sprintf("%s, %s", plural(days, "day"), plural(runs, "run"))
+}
+
+// plural returns n followed by the noun, with an s unless n is 1
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,149 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Calendar_DayCounts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		year     int
+		month    time.Month
+		expected map[int]int
+	}{
+		{"Every_Day", "CRON_TZ=UTC 0 */6 * * * /cmd", 2024, time.February, func() map[int]int {
+			result := map[int]int{}
+			for day := 1; day <= 29; day++ {
+				result[day] = 4
+			}
+			return result
+		}()},
+		{"Day_Or", "CRON_TZ=UTC 0 0 1,15 * MON /cmd", 2024, time.June, map[int]int{1: 1, 3: 1, 10: 1, 15: 1, 17: 1, 24: 1}},
+		{"Rules", "CRON_TZ=UTC 0 0 12 ? * 6L", 2024, time.May, map[int]int{31: 1}},
+		{"Seconds", "CRON_TZ=UTC */30 0 9 15 * ?", 2024, time.May, map[int]int{15: 2}},
+		{"Other_Month", "CRON_TZ=UTC 0 0 1 JAN * /cmd", 2024, time.February, map[int]int{}},
+		{"Other_Year", "CRON_TZ=UTC 0 0 12 1 * ? 2025", 2024, time.January, map[int]int{}},
+		{"DST_Forward", "CRON_TZ=Europe/London 30 * * * * /cmd", 2024, time.March, func() map[int]int {
+			result := map[int]int{}
+			for day := 1; day <= 31; day++ {
+				result[day] = 24
+			}
+			result[31] = 23
+			return result
+		}()},
+		{"DST_Back", "CRON_TZ=Europe/London 30 1 * * * /cmd", 2024, time.October, func() map[int]int {
+			result := map[int]int{}
+			for day := 1; day <= 31; day++ {
+				result[day] = 1
+			}
+			return result
+		}()},
+		{"Reboot", "@reboot /cmd", 2024, time.February, map[int]int{}},
+		{"Every", "@every 1h /cmd", 2024, time.February, map[int]int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			counts := c.DayCounts(tc.year, tc.month)
+			assert.Len(t, counts, daysInMonth(tc.year, tc.month))
+			for i, n := range counts {
+				assert.Equal(t, tc.expected[i+1], n, "day %d", i+1)
+			}
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Calendar_Calendar(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		expected string
+	}{
+		{"Weekdays", "CRON_TZ=UTC */15 0 * * 1-5 /usr/bin/find", `           February 2024
+   Su   Mo   Tu   We   Th   Fr   Sa
+                      [1]  [2]    3
+                       4    4
+    4  [5]  [6]  [7]  [8]  [9]   10
+        4    4    4    4    4
+   11 [12] [13] [14] [15] [16]   17
+        4    4    4    4    4
+   18 [19] [20] [21] [22] [23]   24
+        4    4    4    4    4
+   25 [26] [27] [28] [29]
+        4    4    4    4
+21 days, 84 runs
+`},
+		{"Once", "CRON_TZ=UTC 0 9 29 2 * /usr/bin/report", `           February 2024
+   Su   Mo   Tu   We   Th   Fr   Sa
+                        1    2    3
+    4    5    6    7    8    9   10
+   11   12   13   14   15   16   17
+   18   19   20   21   22   23   24
+   25   26   27   28 [29]
+                       1
+1 day, 1 run
+`},
+		{"Many", "CRON_TZ=UTC * * 1 2 * ?", `           February 2024
+   Su   Mo   Tu   We   Th   Fr   Sa
+                        1  [2]    3
+                         3600
+    4    5    6    7    8    9   10
+   11   12   13   14   15   16   17
+   18   19   20   21   22   23   24
+   25   26   27   28   29
+1 day, 3600 runs
+`},
+		{"None", "CRON_TZ=UTC 0 0 1 JAN * /cmd", `           February 2024
+   Su   Mo   Tu   We   Th   Fr   Sa
+                        1    2    3
+    4    5    6    7    8    9   10
+   11   12   13   14   15   16   17
+   18   19   20   21   22   23   24
+   25   26   27   28   29
+no runs
+`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expected, c.Calendar(2024, time.February))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Calendar_CalendarYear(t *testing.T) {
+	c, _ := Parse("CRON_TZ=UTC 0 0 1 */3 * /cmd")
+	res := c.CalendarYear(2024)
+
+	for _, month := range []string{"January", "June", "December"} {
+		assert.Contains(t, res, month+" 2024\n")
+	}
+	assert.Equal(t, 4, strings.Count(res, "1 day, 1 run\n"))
+	assert.Equal(t, 8, strings.Count(res, "no runs\n"))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Calendar_RunCount(t *testing.T) {
+	assert.Equal(t, "1", runCount(1))
+	assert.Equal(t, "9999", runCount(9999))
+	assert.Equal(t, "10k", runCount(10000))
+	assert.Equal(t, "86k", runCount(86400))
+}
@@ -0,0 +1,252 @@
+package cron
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CI config formats
+//
+// GitHub Actions workflows schedule with on.schedule, in UTC
+//
+//	name: Nightly
+//	on:
+//	  schedule:
+//	    - cron: "30 2 * * *"
+//
+// GitLab pipeline schedules are exported from its API as JSON, one
+// object or a list of them, in cron_timezone (default UTC)
+//
+//	[{"description": "Nightly", "ref": "main", "cron": "30 2 * * *", "cron_timezone": "UTC"}]
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CI platforms
+const (
+	PlatformGitHub = "github"
+	PlatformGitLab = "gitlab"
+)
+
+// githubMinInterval is the shortest interval GitHub runs a schedule at
+const githubMinInterval = 5
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CIConfig represents the schedules found in a CI config file
+type CIConfig struct {
+	Schedules []CISchedule
+	// Errors holds an error for each schedule that could not be parsed
+	Errors []error
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CISchedule represents a single schedule of a CI pipeline
+//
+// Line is the line of the schedule. Name is the workflow name or the
+// GitLab description, and is the command of Cron. TimeZone is only set
+// by GitLab, with both platforms defaulting to UTC
+type CISchedule struct {
+	Line     int
+	Platform string
+	Name     string
+	Schedule string
+	TimeZone string
+	Cron     *Cron
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Header returns the line, platform and name of the schedule as
+// comment lines, to go above its table
+func (s CISchedule) Header() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# line %d\n", s.Line)
+	fmt.Fprintf(&sb, "# %s %s\n", s.Platform, s.Name)
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Findings returns the problems the platform has with the schedule
+//
+// GitHub only accepts 5 fields of numbers, names, *, -, / and commas,
+// and runs a schedule at most every 5 minutes. GitLab has no findings
+func (s CISchedule) Findings() []Finding {
+	if s.Platform != PlatformGitHub {
+		return nil
+	}
+
+	c := s.Cron
+	switch {
+	case strings.HasPrefix(s.Schedule, "@") && c.Kind == KindTime:
+		fields := []string{c.Source.Minute, c.Source.Hour, c.Source.DayOfMonth, c.Source.Month, c.Source.DayOfWeek}
+		return []Finding{githubSyntax(fmt.Sprintf("GitHub rejects %s, use %s", strings.Fields(s.Schedule)[0], strings.Join(fields, " ")))}
+	case strings.HasPrefix(s.Schedule, "@"):
+		return []Finding{githubSyntax(fmt.Sprintf("GitHub rejects %s, as it only accepts 5 fields", strings.Fields(s.Schedule)[0]))}
+	case len(c.DayOfMonthRules) > 0 || len(c.DayOfWeekRules) > 0:
+		return []Finding{githubSyntax("GitHub rejects L, W and #, as it only accepts numbers, names, *, -, / and commas")}
+	}
+
+	if gap := c.minGap(); gap < githubMinInterval {
+		return []Finding{{
+			Rule:     "github-interval",
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("runs %s apart, but GitHub runs a schedule at most every %d minutes", time.Duration(gap)*time.Minute, githubMinInterval),
+		}}
+	}
+
+	return nil
+}
+
+// githubSyntax returns a finding for syntax GitHub rejects
+func githubSyntax(message string) Finding {
+	return Finding{Rule: "github-syntax", Severity: SeverityError, Message: message}
+}
+
+// minGap returns the fewest minutes between two runs on the same or
+// consecutive days
+func (c Cron) minGap() int {
+	var minutes []int
+	for _, h := range c.Hour {
+		for _, m := range c.Minute {
+			minutes = append(minutes, h*60+m)
+		}
+	}
+	if len(minutes) == 0 {
+		return 24 * 60
+	}
+
+	gap := minutes[0] + 24*60 - minutes[len(minutes)-1]
+	for i := 1; i < len(minutes); i++ {
+		if d := minutes[i] - minutes[i-1]; d < gap {
+			gap = d
+		}
+	}
+	return gap
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseCIConfig parses every schedule in a GitHub Actions workflow or
+// a GitLab pipeline schedule export
+//
+// The platform is told from the contents: a workflow has an on key
+// and an export has a cron key in each schedule. Files that are
+// neither have no schedules. A schedule that fails to parse is added
+// to Errors and does not stop the rest of the file being parsed. The
+// dialect is always standard, as both platforms only accept that
+func ParseCIConfig(r io.Reader, opts ParseOptions) (*CIConfig, error) {
+	config := &CIConfig{}
+
+	decoder := yaml.NewDecoder(r)
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("ci - %s", err)
+		}
+
+		for _, node := range doc.Content {
+			config.add(node, opts)
+		}
+	}
+
+	return config, nil
+}
+
+// add adds the schedules of a workflow, or of a GitLab export
+func (cc *CIConfig) add(node *yaml.Node, opts ParseOptions) {
+	switch {
+	case node.Kind == yaml.SequenceNode:
+		for _, item := range node.Content {
+			if yamlPath(item, "cron") != nil {
+				cc.addGitLab(item, opts)
+			}
+		}
+	case yamlPath(node, "cron") != nil:
+		cc.addGitLab(node, opts)
+	case yamlPath(node, "on") != nil:
+		cc.addGitHub(node, opts)
+	}
+}
+
+// addGitHub adds each on.schedule[].cron of a workflow
+func (cc *CIConfig) addGitHub(node *yaml.Node, opts ParseOptions) {
+	name := yamlString(yamlPath(node, "name"))
+	if name == "" {
+		name = "workflow"
+	}
+
+	schedules := yamlPath(node, "on", "schedule")
+	if schedules == nil || schedules.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, item := range schedules.Content {
+		cc.parse(item, CISchedule{Line: item.Line, Platform: PlatformGitHub, Name: name}, "GitHub schedules run in UTC, so write the time in UTC", opts)
+	}
+}
+
+// addGitLab adds a pipeline schedule from an export
+func (cc *CIConfig) addGitLab(node *yaml.Node, opts ParseOptions) {
+	name := yamlString(yamlPath(node, "description"))
+	if name == "" {
+		name = yamlString(yamlPath(node, "ref"))
+	}
+	if name == "" {
+		name = "pipeline"
+	}
+
+	schedule := CISchedule{
+		Line:     node.Line,
+		Platform: PlatformGitLab,
+		Name:     name,
+		TimeZone: yamlString(yamlPath(node, "cron_timezone")),
+	}
+	cc.parse(node, schedule, "set cron_timezone instead (ex \"cron_timezone\": \"Europe/London\")", opts)
+}
+
+// parse parses the cron key of node as the schedule, in its time zone
+// or UTC
+func (cc *CIConfig) parse(node *yaml.Node, schedule CISchedule, tzHint string, opts ParseOptions) {
+	fail := func(err error) {
+		cc.Errors = append(cc.Errors, fmt.Errorf("line %d - %s %s - %w", schedule.Line, schedule.Platform, schedule.Name, err))
+	}
+
+	exp := yamlPath(node, "cron")
+	if exp == nil || exp.Kind != yaml.ScalarNode {
+		fail(fmt.Errorf("cron - missing"))
+		return
+	}
+	schedule.Line = exp.Line
+	schedule.Schedule = exp.Value
+
+	c, err := parseBareSchedule(schedule.Schedule, schedule.Name, schedule.Platform, tzHint, opts)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	c.Location = time.UTC
+	if schedule.TimeZone != "" {
+		loc, err := ParseLocation(schedule.TimeZone)
+		if err != nil {
+			fail(timeZoneError(schedule.TimeZone, 0, schedule.TimeZone))
+			return
+		}
+		c.Location = loc
+	}
+
+	schedule.Cron = c
+	cc.Schedules = append(cc.Schedules, schedule)
+}
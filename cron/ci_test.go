@@ -0,0 +1,187 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+const testWorkflow = `name: Nightly
+on:
+  push:
+    branches: [main]
+  schedule:
+    - cron: "30 2 * * 1-5"
+    - cron: '*/2 * * * *'
+    - cron: "0 2 * *"
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`
+
+const testPipelineSchedules = `[
+  {
+    "id": 13,
+    "description": "Weekly report",
+    "ref": "refs/heads/main",
+    "cron": "0 9 * * MON",
+    "cron_timezone": "Europe/London",
+    "active": true
+  },
+  {
+    "id": 14,
+    "ref": "main",
+    "cron": "0 3 * * *"
+  }
+]
+`
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_CI_ParseCIConfig(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// GitHub Actions workflow
+	t.Run("GitHub", func(t *testing.T) {
+		res, err := ParseCIConfig(strings.NewReader(testWorkflow), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Schedules, 2)
+
+		nightly := res.Schedules[0]
+		assert.Equal(t, 6, nightly.Line)
+		assert.Equal(t, PlatformGitHub, nightly.Platform)
+		assert.Equal(t, "Nightly", nightly.Name)
+		assert.Equal(t, "30 2 * * 1-5", nightly.Schedule)
+		assert.Equal(t, IntSlice{1, 2, 3, 4, 5}, nightly.Cron.DayOfWeek)
+		assert.Equal(t, "UTC", nightly.Cron.Location.String())
+		assert.Equal(t, "Nightly", nightly.Cron.Command)
+
+		assert.Equal(t, 7, res.Schedules[1].Line)
+		assert.Equal(t, "*/2 * * * *", res.Schedules[1].Schedule)
+
+		assert.Len(t, res.Errors, 1)
+		assert.EqualError(t, res.Errors[0], "line 8 - github Nightly - not enough parts in the cron expression")
+
+		var pe *ParseError
+		assert.True(t, errors.As(res.Errors[0], &pe))
+		assert.Equal(t, "0 2 * *", pe.Expression)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// GitLab pipeline schedule export
+	t.Run("GitLab", func(t *testing.T) {
+		res, err := ParseCIConfig(strings.NewReader(testPipelineSchedules), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Empty(t, res.Errors)
+		assert.Len(t, res.Schedules, 2)
+
+		report := res.Schedules[0]
+		assert.Equal(t, 6, report.Line)
+		assert.Equal(t, PlatformGitLab, report.Platform)
+		assert.Equal(t, "Weekly report", report.Name)
+		assert.Equal(t, "Europe/London", report.Cron.Location.String())
+
+		main := res.Schedules[1]
+		assert.Equal(t, 13, main.Line)
+		assert.Equal(t, "main", main.Name)
+		assert.Empty(t, main.TimeZone)
+		assert.Equal(t, "UTC", main.Cron.Location.String())
+
+		// A single schedule
+		res, err = ParseCIConfig(strings.NewReader(`{"description": "Nightly", "cron": "0 2 * * *"}`), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Schedules, 1)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Errors
+	t.Run("Errors", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			content  string
+			expected string
+		}{
+			{"Missing", "on:\n  schedule:\n    - branches: [main]\n", "line 3 - github workflow - cron - missing"},
+			{"TZ", "on:\n  schedule:\n    - cron: \"CRON_TZ=Europe/London 0 2 * * *\"\n", "line 3 - github workflow - parsing error - github - a time zone in the schedule is rejected"},
+			{"Fields", "on:\n  schedule:\n    - cron: \"0 0 2 * * *\"\n", "line 3 - github workflow - parsing error - github - too many fields"},
+			{"TimeZone", `[{"cron": "0 2 * * *", "cron_timezone": "Pacific Time (US & Canada)"}]`, "line 1 - gitlab pipeline - parsing error - time zone - invalid - Pacific Time (US & Canada)"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				res, err := ParseCIConfig(strings.NewReader(tc.content), ParseOptions{})
+				assert.Nil(t, err)
+				assert.Empty(t, res.Schedules)
+				if assert.Len(t, res.Errors, 1) {
+					assert.EqualError(t, res.Errors[0], tc.expected)
+				}
+			})
+		}
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Files without schedules
+	t.Run("Empty", func(t *testing.T) {
+		for _, content := range []string{"on: [push]\n", "on:\n  push:\n", "kind: ConfigMap\n", "[]"} {
+			res, err := ParseCIConfig(strings.NewReader(content), ParseOptions{})
+			assert.Nil(t, err)
+			assert.Empty(t, res.Schedules)
+			assert.Empty(t, res.Errors)
+		}
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid YAML
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseCIConfig(strings.NewReader("on:\n  schedule:\n    - cron: \"0 2 * * *\n"), ParseOptions{})
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "ci - "))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Malformed YAML is an error, not a panic
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := ParseCIConfig(strings.NewReader("0: [:!00 \xef"), ParseOptions{})
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "ci - "))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_CI_Findings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		platform string
+		schedule string
+		expected []Finding
+	}{
+		{"Valid", PlatformGitHub, "*/5 * * * 1-5", nil},
+		{"Interval", PlatformGitHub, "*/2 * * * *", []Finding{{"github-interval", SeverityWarning, "runs 2m0s apart, but GitHub runs a schedule at most every 5 minutes"}}},
+		{"Midnight", PlatformGitHub, "0,58 0,23 * * *", []Finding{{"github-interval", SeverityWarning, "runs 2m0s apart, but GitHub runs a schedule at most every 5 minutes"}}},
+		{"Macro", PlatformGitHub, "@weekly", []Finding{{"github-syntax", SeverityError, "GitHub rejects @weekly, use 0 0 * * 0"}}},
+		{"Every", PlatformGitHub, "@every 1h", []Finding{{"github-syntax", SeverityError, "GitHub rejects @every, as it only accepts 5 fields"}}},
+		{"Rules", PlatformGitHub, "0 2 L * *", []Finding{{"github-syntax", SeverityError, "GitHub rejects L, W and #, as it only accepts numbers, names, *, -, / and commas"}}},
+		{"GitLab", PlatformGitLab, "* * * * *", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := parseBareSchedule(tc.schedule, "Nightly", tc.platform, "", ParseOptions{})
+			assert.Nil(t, err)
+
+			s := CISchedule{Platform: tc.platform, Schedule: tc.schedule, Cron: c}
+			assert.Equal(t, tc.expected, s.Findings())
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_CI_Header(t *testing.T) {
+	s := CISchedule{Line: 6, Platform: PlatformGitHub, Name: "Nightly"}
+	assert.Equal(t, "# line 6\n# github Nightly\n", s.Header())
+}
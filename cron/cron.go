@@ -0,0 +1,183 @@
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gookit/goutil/arrutil"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Cron represents a single cron expression
+//
+// The time fields are only used when Kind is KindTime. A day matches
+// when it is in the day slice or satisfies one of the day rules, and
+// Days decides if the day of month and day of week must both match.
+// An empty Second runs at second 0 and an empty Year runs every year.
+// Location is the time zone from a CRON_TZ or TZ prefix, if any, and
+// DST is how runs in a daylight saving time change are handled.
+// Warnings are the unrecognised tokens skipped when parsing leniently
+type Cron struct {
+	Original        string         `table:"-"`
+	Source          Source         `table:"-"`
+	Kind            Kind           `table:"kind,omitempty"`
+	Interval        time.Duration  `table:"interval,omitempty"`
+	Second          IntSlice       `table:"second,omitempty"`
+	Minute          IntSlice       `table:"minute"`
+	Hour            IntSlice       `table:"hour"`
+	DayOfMonth      IntSlice       `table:"day of month"`
+	DayOfMonthRules DayRules       `table:"day of month rules,omitempty"`
+	Month           IntSlice       `table:"month"`
+	DayOfWeek       IntSlice       `table:"day of week"`
+	DayOfWeekRules  DayRules       `table:"day of week rules,omitempty"`
+	Days            Days           `table:"days,omitempty"`
+	DST             DSTPolicy      `table:"-"`
+	Year            IntSlice       `table:"year,omitempty"`
+	Location        *time.Location `table:"time zone,omitempty"`
+	User            string         `table:"user,omitempty"`
+	Command         string         `table:"command"`
+	Warnings        ParseErrors    `table:"-"`
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Source records how the time fields of an expression were written,
+// before they were expanded, along with the dialect they were parsed
+// in. Fields the dialect does not have are empty
+//
+// Macros record the fields they expand to
+type Source struct {
+	Dialect    Dialect
+	Second     string
+	Minute     string
+	Hour       string
+	DayOfMonth string
+	Month      string
+	DayOfWeek  string
+	Year       string
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Kind is the type of schedule a Cron represents
+type Kind int
+
+const (
+	// KindTime runs at the times matched by the time fields
+	KindTime Kind = iota
+	// KindReboot runs once when the cron daemon starts (@reboot)
+	KindReboot
+	// KindInterval runs at a fixed interval from when the cron
+	// daemon starts (@every)
+	KindInterval
+)
+
+// String returns a string representation of Kind
+func (k Kind) String() string {
+	switch k {
+	case KindReboot:
+		return "reboot"
+	case KindInterval:
+		return "interval"
+	}
+	return "time"
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// IntSlice represents an int
+type IntSlice []int
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns a string representation of IntSlice
+func (i IntSlice) String() string {
+	// Ignore the error. In this case it will just
+	// be an empty slice
+	r, _ := arrutil.ToStrings(i)
+	return strings.Join(r, " ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Contains checks if a value is in the IntSlice
+func (i IntSlice) Contains(value int) bool {
+	for _, v := range i {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String generates a stringified version of the struct
+func (c Cron) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Minute: %s\n", c.Minute.String())
+	fmt.Fprintf(&sb, "Hour: %s\n", c.Hour.String())
+	fmt.Fprintf(&sb, "Day of Month: %s\n", c.DayOfMonth.String())
+	fmt.Fprintf(&sb, "Month: %s\n", c.Month.String())
+	fmt.Fprintf(&sb, "Day of Week: %s\n", c.DayOfWeek.String())
+	fmt.Fprintf(&sb, "Command: %s", c.Command)
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Table returns the struct in table format
+//
+// Rows come from the table tag of each field. A tag of "-" skips the
+// field and the omitempty option skips it when it is the zero value,
+// or its IsZero method says so
+func (c Cron) Table() string {
+	tagging := "table"
+
+	// String builder (for the tabwriter)
+	var sb strings.Builder
+
+	// Tabwriter
+	w := new(tabwriter.Writer)
+	w.Init(&sb, 0, 0, 2, ' ', 0)
+	v := reflect.ValueOf(c)
+
+	for i := 0; i < v.NumField(); i++ {
+		// Field tag value and options
+		tag, options, _ := strings.Cut(v.Type().Field(i).Tag.Get(tagging), ",")
+
+		// Skip if tag is not defined or ignored
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		// Skip if empty and omitempty is set
+		if options == "omitempty" && isZero(v.Field(i)) {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", tag, v.Field(i).Interface())
+	}
+
+	// Flush
+	w.Flush()
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// isZero checks if a field is empty, using its IsZero method when it
+// has one
+func isZero(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
@@ -1,8 +1,10 @@
-package main
+package cron
 
 import (
 	"testing"
 
+	"time"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,16 +44,24 @@ func Test_Cron_IntSlice(t *testing.T) {
 	t.Run("Basic", func(t *testing.T) {
 		assert.Equal(t, IntSlice{1, 2, 3}.String(), "1 2 3")
 	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Contains
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, IntSlice{1, 2, 3}.Contains(2))
+		assert.False(t, IntSlice{1, 2, 3}.Contains(4))
+		assert.False(t, IntSlice{}.Contains(0))
+	})
 }
 
 // ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-func Test_Cron_PrintTable(t *testing.T) {
+func Test_Cron_Table(t *testing.T) {
 	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
 	// Empty
 	t.Run("Empty", func(t *testing.T) {
 		c := &Cron{}
-		out := CaptureOutput(c.PrintTable)
+		out := c.Table()
 
 		expected := `minute        
 hour          
@@ -68,7 +78,7 @@ command
 	// Partially populated
 	t.Run("Partially Populated", func(t *testing.T) {
 		c := &Cron{Minute: IntSlice{1, 2, 3}, Month: IntSlice{0, 11}, Command: "/this/is/a/test"}
-		out := CaptureOutput(c.PrintTable)
+		out := c.Table()
 
 		expected := `minute        1 2 3
 hour          
@@ -92,7 +102,7 @@ command       /this/is/a/test
 			DayOfWeek:  []int{7},
 			Command:    "/this/is/a/test",
 		}
-		out := CaptureOutput(c.PrintTable)
+		out := c.Table()
 
 		expected := `minute        0 15 30 45
 hour          17 18 21
@@ -104,4 +114,76 @@ command       /this/is/a/test
 
 		assert.Equal(t, expected, out)
 	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Reboot
+	t.Run("Reboot", func(t *testing.T) {
+		c := &Cron{Kind: KindReboot, Command: "/this/is/a/test"}
+		out := c.Table()
+
+		expected := `kind          reboot
+minute        
+hour          
+day of month  
+month         
+day of week   
+command       /this/is/a/test
+`
+
+		assert.Equal(t, expected, out)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Interval
+	t.Run("Interval", func(t *testing.T) {
+		c := &Cron{Kind: KindInterval, Interval: 90 * time.Minute, Command: "/this/is/a/test"}
+		out := c.Table()
+
+		expected := `kind          interval
+interval      1h30m0s
+minute        
+hour          
+day of month  
+month         
+day of week   
+command       /this/is/a/test
+`
+
+		assert.Equal(t, expected, out)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_Table_Rules(t *testing.T) {
+	c := &Cron{
+		Minute:          IntSlice{0},
+		Hour:            IntSlice{0},
+		DayOfMonthRules: DayRules{{Kind: LastDayOfMonth}},
+		Month:           IntSlice{1},
+		DayOfWeek:       IntSlice{1},
+		DayOfWeekRules:  DayRules{{Kind: NthDayOfWeek, Day: 5, N: 3}},
+		Command:         "/this/is/a/test",
+	}
+	out := c.Table()
+
+	expected := `minute              0
+hour                0
+day of month        
+day of month rules  L
+month               1
+day of week         1
+day of week rules   5#3
+command             /this/is/a/test
+`
+
+	assert.Equal(t, expected, out)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_Kind(t *testing.T) {
+	assert.Equal(t, "time", KindTime.String())
+	assert.Equal(t, "reboot", KindReboot.String())
+	assert.Equal(t, "interval", KindInterval.String())
 }
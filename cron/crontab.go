@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Crontab represents a parsed crontab file
+type Crontab struct {
+	Entries []Entry
+	// Errors holds an error for each line that could not be parsed
+	Errors []error
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Entry represents a single job in a crontab
+//
+// Comments are the comment lines directly above the job and Env
+// holds the environment variables (ex MAILTO, SHELL, PATH, CRON_TZ)
+// set before it
+type Entry struct {
+	Line     int               `json:"line" yaml:"line"`
+	Comments []string          `json:"comments,omitempty" yaml:"comments,omitempty"`
+	Env      map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	Cron     *Cron             `json:"cron" yaml:"cron"`
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// envRegex matches an environment variable line (ex MAILTO=root or
+// SHELL = /bin/bash)
+var envRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseCrontab parses every job in a crontab
+//
+// Blank lines and comments are skipped, with the comments directly
+// above a job attached to it. NAME=value lines set the environment of
+// the jobs that follow them. A line that fails to parse is added to
+// Errors and does not stop the rest of the crontab being parsed
+func ParseCrontab(r io.Reader, opts ParseOptions) (*Crontab, error) {
+	var (
+		crontab  = &Crontab{}
+		env      = map[string]string{}
+		comments []string
+		line     int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		// Blank lines end a block of comments
+		if text == "" {
+			comments = nil
+			continue
+		}
+
+		// Comment
+		if strings.HasPrefix(text, "#") {
+			comments = append(comments, strings.TrimSpace(strings.TrimPrefix(text, "#")))
+			continue
+		}
+
+		// Environment variable. The map is copied so jobs above keep
+		// the environment they were given
+		if match := envRegex.FindStringSubmatch(text); match != nil {
+			env = copyEnv(env)
+			env[match[1]] = unquote(match[2])
+			comments = nil
+			continue
+		}
+
+		// Job
+		cron, err := parseEntry(text, env, opts)
+		if err != nil {
+			crontab.Errors = append(crontab.Errors, fmt.Errorf("line %d - %w", line, err))
+		} else {
+			crontab.Entries = append(crontab.Entries, Entry{
+				Line:     line,
+				Comments: comments,
+				Env:      env,
+				Cron:     cron,
+			})
+		}
+
+		comments = nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("crontab - %s", err)
+	}
+
+	return crontab, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseEntry parses a job line. A CRON_TZ variable set above the job
+// is its time zone
+func parseEntry(text string, env map[string]string, opts ParseOptions) (*Cron, error) {
+	cron, err := ParseWithOptions(text, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if name, ok := env["CRON_TZ"]; ok {
+		loc, err := ParseLocation(name)
+		if err != nil {
+			return nil, timeZoneError("CRON_TZ="+name, len("CRON_TZ="), name)
+		}
+		cron.Location = loc
+	}
+
+	return cron, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Table returns the entry in table format, preceded by its Header
+func (e Entry) Table() string {
+	return e.Header() + e.Cron.Table()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Header returns the line number, comments and environment of the
+// entry, as crontab lines
+func (e Entry) Header() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# line %d\n", e.Line)
+	for _, comment := range e.Comments {
+		fmt.Fprintf(&sb, "# %s\n", comment)
+	}
+
+	// Sorted so the output is stable
+	names := make([]string, 0, len(e.Env))
+	for name := range e.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s=%s\n", name, e.Env[name])
+	}
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// copyEnv returns a copy of an environment map
+func copyEnv(env map[string]string) map[string]string {
+	result := make(map[string]string, len(env)+1)
+	for k, v := range env {
+		result[k] = v
+	}
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// unquote removes matching single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+const testCrontab = `# Edit this file to introduce tasks to be run by cron.
+SHELL=/bin/bash
+MAILTO="ops@example.com"
+
+# nightly backup
+# keeps 7 days
+0 2	* *   *   /usr/bin/backup --all
+
+# broken
+61 * * * * /usr/bin/broken
+PATH = '/usr/bin:/bin'
+@hourly /usr/bin/report
+`
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Crontab_ParseCrontab(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Entries
+	t.Run("Entries", func(t *testing.T) {
+		res, err := ParseCrontab(strings.NewReader(testCrontab), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Entries, 2)
+
+		backup := res.Entries[0]
+		assert.Equal(t, 7, backup.Line)
+		assert.Equal(t, []string{"nightly backup", "keeps 7 days"}, backup.Comments)
+		assert.Equal(t, map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"}, backup.Env)
+		assert.Equal(t, IntSlice{2}, backup.Cron.Hour)
+		assert.Equal(t, "/usr/bin/backup --all", backup.Cron.Command)
+
+		report := res.Entries[1]
+		assert.Equal(t, 12, report.Line)
+		assert.Empty(t, report.Comments)
+		assert.Equal(t, map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com", "PATH": "/usr/bin:/bin"}, report.Env)
+		assert.Equal(t, "@hourly /usr/bin/report", report.Cron.Original)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Errors
+	t.Run("Errors", func(t *testing.T) {
+		res, err := ParseCrontab(strings.NewReader(testCrontab), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Errors, 1)
+		assert.EqualError(t, res.Errors[0], "line 10 - parsing error - minute - invalid")
+
+		// The line keeps the position of the error
+		var pe *ParseError
+		assert.True(t, errors.As(res.Errors[0], &pe))
+		assert.Equal(t, "minute", pe.Field)
+		assert.Equal(t, 0, pe.Column)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// System
+	t.Run("System", func(t *testing.T) {
+		content := "SHELL=/bin/sh\n17 * * * * root cd / && run-parts --report /etc/cron.hourly\n"
+
+		res, err := ParseCrontab(strings.NewReader(content), ParseOptions{System: true})
+		assert.Nil(t, err)
+		assert.Len(t, res.Entries, 1)
+		assert.Equal(t, "root", res.Entries[0].Cron.User)
+		assert.Equal(t, "cd / && run-parts --report /etc/cron.hourly", res.Entries[0].Cron.Command)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// CRON_TZ applies to the jobs below it
+	t.Run("Cron_TZ", func(t *testing.T) {
+		content := "0 9 * * * /a\nCRON_TZ=Europe/London\n0 9 * * * /b\nCRON_TZ=Mars/Olympus\n0 9 * * * /c\n"
+
+		res, err := ParseCrontab(strings.NewReader(content), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Entries, 2)
+		assert.Nil(t, res.Entries[0].Cron.Location)
+		assert.Equal(t, "Europe/London", res.Entries[1].Cron.Location.String())
+
+		assert.Len(t, res.Errors, 1)
+		assert.EqualError(t, res.Errors[0], "line 5 - parsing error - time zone - invalid - Mars/Olympus")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Empty
+	t.Run("Empty", func(t *testing.T) {
+		res, err := ParseCrontab(strings.NewReader("# nothing here\n\n"), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Empty(t, res.Entries)
+		assert.Empty(t, res.Errors)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Crontab_EntryTable(t *testing.T) {
+	e := Entry{
+		Line:     4,
+		Comments: []string{"a comment"},
+		Env:      map[string]string{"SHELL": "/bin/sh", "MAILTO": "root"},
+		Cron:     &Cron{Minute: IntSlice{1}, Command: "/this/is/a/test"},
+	}
+
+	expected := `# line 4
+# a comment
+MAILTO=root
+SHELL=/bin/sh
+minute        1
+hour          
+day of month  
+month         
+day of week   
+command       /this/is/a/test
+`
+
+	assert.Equal(t, expected, e.Table())
+	assert.Equal(t, "# line 4\n# a comment\nMAILTO=root\nSHELL=/bin/sh\n", e.Header())
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Crontab_Unquote(t *testing.T) {
+	assert.Equal(t, "value", unquote(`"value"`))
+	assert.Equal(t, "value", unquote(`'value'`))
+	assert.Equal(t, "", unquote(`""`))
+	assert.Equal(t, `"value'`, unquote(`"value'`))
+	assert.Equal(t, "value", unquote("value"))
+}
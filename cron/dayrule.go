@@ -0,0 +1,254 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DayRuleKind is the type of a DayRule
+type DayRuleKind int
+
+const (
+	// LastDayOfMonth is the last day of the month, less N days
+	// (ex L or L-3)
+	LastDayOfMonth DayRuleKind = iota
+	// LastWeekdayOfMonth is the last Monday to Friday of the
+	// month (ex LW)
+	LastWeekdayOfMonth
+	// NearestWeekday is the Monday to Friday nearest to Day,
+	// without leaving the month (ex 15W)
+	NearestWeekday
+	// LastDayOfWeek is the last Day of the week in the month
+	// (ex 5L)
+	LastDayOfWeek
+	// NthDayOfWeek is the Nth Day of the week in the month
+	// (ex 5#3)
+	NthDayOfWeek
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DayRule represents a Quartz style day of month or day of week
+// value, which depends on the month it falls in
+//
+// Day is a day of month for NearestWeekday and a day of week (0 - 6)
+// for LastDayOfWeek and NthDayOfWeek. N is the offset for
+// LastDayOfMonth and the occurrence for NthDayOfWeek
+type DayRule struct {
+	Kind DayRuleKind
+	Day  int
+	N    int
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns the rule as it is written in an expression
+func (r DayRule) String() string {
+	switch r.Kind {
+	case LastDayOfMonth:
+		if r.N > 0 {
+			return fmt.Sprintf("L-%d", r.N)
+		}
+		return "L"
+	case LastWeekdayOfMonth:
+		return "LW"
+	case NearestWeekday:
+		return fmt.Sprintf("%dW", r.Day)
+	case LastDayOfWeek:
+		return fmt.Sprintf("%dL", r.Day)
+	case NthDayOfWeek:
+		return fmt.Sprintf("%d#%d", r.Day, r.N)
+	}
+	return ""
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Matches checks if the day of t satisfies the rule
+func (r DayRule) Matches(t time.Time) bool {
+	year, month, day := t.Date()
+	last := daysInMonth(year, month)
+
+	switch r.Kind {
+	case LastDayOfMonth:
+		return day == last-r.N
+	case LastWeekdayOfMonth:
+		return day == nearestWeekday(year, month, last)
+	case NearestWeekday:
+		return day == nearestWeekday(year, month, r.Day)
+	case LastDayOfWeek:
+		return int(t.Weekday()) == r.Day && day+7 > last
+	case NthDayOfWeek:
+		return int(t.Weekday()) == r.Day && (day-1)/7+1 == r.N
+	}
+	return false
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DayRules represents a list of DayRule
+type DayRules []DayRule
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns a string representation of DayRules
+func (r DayRules) String() string {
+	result := make([]string, len(r))
+	for i, rule := range r {
+		result[i] = rule.String()
+	}
+	return strings.Join(result, " ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Matches checks if the day of t satisfies any of the rules
+func (r DayRules) Matches(t time.Time) bool {
+	for _, rule := range r {
+		if rule.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// daysInMonth returns the number of days in a month
+func daysInMonth(year int, month time.Month) int {
+	// Day 0 of the following month is the last day of this month
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// nearestWeekday returns the Monday to Friday closest to a day,
+// without leaving the month
+//
+// 0 is returned when the day is not in the month
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := daysInMonth(year, month)
+	if day > last {
+		return 0
+	}
+
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		// Friday, or Monday when the 1st is a Saturday
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		// Monday, or Friday when the last day is a Sunday
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+
+	return day
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DayMatch is how the day of month and day of week fields combine
+// when both are restricted
+type DayMatch int
+
+const (
+	// DayMatchOr runs on a day that matches either field, as in Vixie
+	// cron. When one of the fields starts with * only the other
+	// field is used
+	DayMatchOr DayMatch = iota
+	// DayMatchAnd runs on a day that matches both fields
+	DayMatchAnd
+)
+
+// String returns a string representation of DayMatch
+func (d DayMatch) String() string {
+	if d == DayMatchAnd {
+		return "and"
+	}
+	return "or"
+}
+
+// ParseDayMatch returns the DayMatch with the given name
+func ParseDayMatch(name string) (DayMatch, error) {
+	switch strings.ToLower(name) {
+	case "or":
+		return DayMatchOr, nil
+	case "and":
+		return DayMatchAnd, nil
+	}
+
+	return DayMatchOr, fmt.Errorf("day match - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Days records whether the day of month and day of week fields were
+// restricted and how they combine
+//
+// A field is restricted unless it starts with * or is ?, so */2 is
+// not restricted, following Vixie cron
+type Days struct {
+	Match                DayMatch
+	DayOfMonthRestricted bool
+	DayOfWeekRestricted  bool
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Either checks if a day only needs to match one of the fields
+func (d Days) Either() bool {
+	return d.Match == DayMatchOr && d.DayOfMonthRestricted && d.DayOfWeekRestricted
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// IsZero checks if how the fields combine makes no difference, which
+// is the case unless both are restricted
+func (d Days) IsZero() bool {
+	return !d.DayOfMonthRestricted || !d.DayOfWeekRestricted
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns how the fields combine (ex day of month or day of
+// week), or an empty string when it makes no difference
+func (d Days) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("day of month %s day of week", d.Match)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// dayFields checks if the day of month and day of week fields limit
+// the days the schedule runs on
+//
+// Days only says how the fields combine, as a field starting with *
+// (ex */2) is not restricted but can still leave days out. A field
+// leaves days out when it has fewer values than the whole field, and
+// when either field matching is enough a whole field matches every day
+func (c Cron) dayFields() (dom, dow bool) {
+	dom = len(c.DayOfMonth) < len(defaultDomSlice)
+	dow = len(c.DayOfWeek) < len(defaultDowSlice)
+	if c.Days.Either() && (!dom || !dow) {
+		return false, false
+	}
+	return dom, dow
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// restrictsDays checks if a day of month or day of week segment
+// restricts the days, see Days
+func restrictsDays(seg string) bool {
+	return !strings.HasPrefix(seg, "*") && seg != "?"
+}
@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DayRule_String(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     DayRule
+		expected string
+	}{
+		{"Last", DayRule{Kind: LastDayOfMonth}, "L"},
+		{"Last_Offset", DayRule{Kind: LastDayOfMonth, N: 3}, "L-3"},
+		{"Last_Weekday", DayRule{Kind: LastWeekdayOfMonth}, "LW"},
+		{"Nearest_Weekday", DayRule{Kind: NearestWeekday, Day: 15}, "15W"},
+		{"Last_Day_Of_Week", DayRule{Kind: LastDayOfWeek, Day: 5}, "5L"},
+		{"Nth_Day_Of_Week", DayRule{Kind: NthDayOfWeek, Day: 5, N: 3}, "5#3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.rule.String())
+		})
+	}
+
+	assert.Equal(t, "L 15W", DayRules{{Kind: LastDayOfMonth}, {Kind: NearestWeekday, Day: 15}}.String())
+	assert.Equal(t, "", DayRules{}.String())
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DayRule_Matches(t *testing.T) {
+	// May 2022 starts on a Sunday and ends on a Tuesday. October
+	// 2022 starts on a Saturday and ends on a Monday. July 2022 ends
+	// on a Sunday
+	testCases := []struct {
+		name     string
+		rule     DayRule
+		day      time.Time
+		expected bool
+	}{
+		{"Last", DayRule{Kind: LastDayOfMonth}, date(2022, 5, 31, 0, 0), true},
+		{"Last_Not", DayRule{Kind: LastDayOfMonth}, date(2022, 5, 30, 0, 0), false},
+		{"Last_February", DayRule{Kind: LastDayOfMonth}, date(2024, 2, 29, 0, 0), true},
+		{"Last_Offset", DayRule{Kind: LastDayOfMonth, N: 3}, date(2022, 5, 28, 0, 0), true},
+		{"Last_Weekday", DayRule{Kind: LastWeekdayOfMonth}, date(2022, 5, 31, 0, 0), true},
+		{"Last_Weekday_Sunday", DayRule{Kind: LastWeekdayOfMonth}, date(2022, 7, 29, 0, 0), true},
+		{"Last_Weekday_Sunday_Not", DayRule{Kind: LastWeekdayOfMonth}, date(2022, 7, 31, 0, 0), false},
+		{"Nearest_Weekday", DayRule{Kind: NearestWeekday, Day: 10}, date(2022, 5, 10, 0, 0), true},
+		{"Nearest_Weekday_Saturday", DayRule{Kind: NearestWeekday, Day: 14}, date(2022, 5, 13, 0, 0), true},
+		{"Nearest_Weekday_Sunday", DayRule{Kind: NearestWeekday, Day: 15}, date(2022, 5, 16, 0, 0), true},
+		{"Nearest_Weekday_First_Saturday", DayRule{Kind: NearestWeekday, Day: 1}, date(2022, 10, 3, 0, 0), true},
+		{"Nearest_Weekday_Last_Sunday", DayRule{Kind: NearestWeekday, Day: 31}, date(2022, 7, 29, 0, 0), true},
+		{"Nearest_Weekday_Missing_Day", DayRule{Kind: NearestWeekday, Day: 31}, date(2022, 6, 30, 0, 0), false},
+		{"Last_Day_Of_Week", DayRule{Kind: LastDayOfWeek, Day: 5}, date(2022, 5, 27, 0, 0), true},
+		{"Last_Day_Of_Week_Not", DayRule{Kind: LastDayOfWeek, Day: 5}, date(2022, 5, 20, 0, 0), false},
+		{"Nth_Day_Of_Week", DayRule{Kind: NthDayOfWeek, Day: 5, N: 3}, date(2022, 5, 20, 0, 0), true},
+		{"Nth_Day_Of_Week_Not", DayRule{Kind: NthDayOfWeek, Day: 5, N: 3}, date(2022, 5, 13, 0, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.rule.Matches(tc.day))
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Any rule
+	t.Run("Rules", func(t *testing.T) {
+		rules := DayRules{{Kind: LastDayOfMonth}, {Kind: NearestWeekday, Day: 15}}
+		assert.True(t, rules.Matches(date(2022, 5, 31, 0, 0)))
+		assert.True(t, rules.Matches(date(2022, 5, 16, 0, 0)))
+		assert.False(t, rules.Matches(date(2022, 5, 15, 0, 0)))
+		assert.False(t, DayRules{}.Matches(date(2022, 5, 31, 0, 0)))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DayRule_Days(t *testing.T) {
+	testCases := []struct {
+		name     string
+		days     Days
+		either   bool
+		expected string
+	}{
+		{"Neither", Days{}, false, ""},
+		{"Day_Of_Month", Days{DayOfMonthRestricted: true}, false, ""},
+		{"Day_Of_Week", Days{DayOfWeekRestricted: true}, false, ""},
+		{"Both", Days{DayOfMonthRestricted: true, DayOfWeekRestricted: true}, true, "day of month or day of week"},
+		{"Both_And", Days{Match: DayMatchAnd, DayOfMonthRestricted: true, DayOfWeekRestricted: true}, false, "day of month and day of week"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.either, tc.days.Either())
+			assert.Equal(t, tc.expected == "", tc.days.IsZero())
+			assert.Equal(t, tc.expected, tc.days.String())
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Restricted segments
+	assert.True(t, restrictsDays("1"))
+	assert.True(t, restrictsDays("MON-FRI"))
+	assert.True(t, restrictsDays("L"))
+	assert.False(t, restrictsDays("*"))
+	assert.False(t, restrictsDays("*/2"))
+	assert.False(t, restrictsDays("?"))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DayRule_ParseDayMatch(t *testing.T) {
+	match, err := ParseDayMatch("AND")
+	assert.Nil(t, err)
+	assert.Equal(t, DayMatchAnd, match)
+
+	match, err = ParseDayMatch("or")
+	assert.Nil(t, err)
+	assert.Equal(t, DayMatchOr, match)
+
+	_, err = ParseDayMatch("xor")
+	assert.EqualError(t, err, "day match - unknown - xor")
+}
@@ -0,0 +1,359 @@
+package cron
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Names used when describing an expression
+var (
+	monthNames = []string{
+		"", "January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	}
+
+	dayNames = []string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	}
+
+	nthNames = []string{"", "first", "second", "third", "fourth", "fifth"}
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// unit describes how the values of a field are named
+//
+// Values are preceded by the unit name when prefix is set (ex minute 5
+// rather than January). The replacer turns names into numbers and
+// rules splits out any Quartz rules
+type unit struct {
+	name     string
+	prefix   bool
+	value    func(int) string
+	replacer *strings.Replacer
+	rules    func(string) (string, DayRules, error)
+}
+
+// Units of each field
+var (
+	secondUnit = unit{name: "second", prefix: true}
+	minuteUnit = unit{name: "minute", prefix: true}
+	hourUnit   = unit{name: "hour", prefix: true}
+	domUnit    = unit{name: "day-of-month", prefix: true, rules: extractDomRules}
+	monthUnit  = unit{
+		name:     "month",
+		value:    func(n int) string { return monthNames[n] },
+		replacer: defaultMonthSliceReplacer,
+	}
+	yearUnit = unit{name: "year"}
+)
+
+// dowUnit returns the unit of the day of week field, which is
+// numbered from 1 in Quartz
+func dowUnit(quartz bool) unit {
+	first, replacer := 0, defaultDowSliceReplacer
+	if quartz {
+		first, replacer = 1, quartzDowSliceReplacer
+	}
+
+	return unit{
+		name:     "day-of-week",
+		value:    func(n int) string { return dayNames[(n-first+7)%7] },
+		replacer: replacer,
+		rules: func(exp string) (string, DayRules, error) {
+			return extractDowRules(exp, quartz)
+		},
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Describe returns an English description of when the expression
+// runs (ex At every 15th minute past hour 0 on day-of-month 1 and 15)
+//
+// The description follows how the expression was written, so */15 is
+// every 15th minute rather than minute 0, 15, 30 and 45
+func (c Cron) Describe() string {
+	switch c.Kind {
+	case KindReboot:
+		return "At reboot."
+	case KindInterval:
+		return fmt.Sprintf("Every %s.", c.Interval)
+	}
+
+	src := c.Source
+
+	var sb strings.Builder
+	sb.WriteString("At " + describeTime(src))
+
+	// Day of month and day of week
+	dom := isRestricted(src.DayOfMonth)
+	if dom {
+		sb.WriteString(" on " + describeSegment(src.DayOfMonth, domUnit))
+	}
+
+	if isRestricted(src.DayOfWeek) {
+		if dom {
+			sb.WriteString(" " + c.dayJoin())
+		}
+		sb.WriteString(" on " + describeSegment(src.DayOfWeek, dowUnit(src.Dialect.quartzWeekdays())))
+	}
+
+	// Month
+	if isRestricted(src.Month) {
+		sb.WriteString(" in " + describeSegment(src.Month, monthUnit))
+	}
+
+	// Year
+	if isRestricted(src.Year) {
+		sb.WriteString(" in " + describeSegment(src.Year, yearUnit))
+	}
+
+	// Time zone
+	if c.Location != nil {
+		sb.WriteString(" (" + c.Location.String() + ")")
+	}
+
+	sb.WriteString(".")
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// dayJoin returns the word joining the day of month and day of week
+// descriptions, which is or when either day is enough (see Days)
+func (c Cron) dayJoin() string {
+	if c.Days.Either() {
+		return "or"
+	}
+	return "and"
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// describeTime describes the second, minute and hour fields
+//
+// A single second, minute and hour is given as a time (ex 09:30)
+func describeTime(src Source) string {
+	single := regexp.MustCompile(`^\d+$`)
+
+	if single.MatchString(src.Minute) && single.MatchString(src.Hour) &&
+		(src.Second == "" || single.MatchString(src.Second)) {
+		hour, _ := strconv.Atoi(src.Hour)
+		minute, _ := strconv.Atoi(src.Minute)
+		second, _ := strconv.Atoi(src.Second)
+
+		if second == 0 {
+			return fmt.Sprintf("%02d:%02d", hour, minute)
+		}
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+
+	var parts []string
+
+	// Seconds are left out when the job runs at the start of the
+	// minute, which is the case for all standard expressions
+	seconds := src.Second != "" && src.Second != "0"
+	if seconds {
+		parts = append(parts, describeSegment(src.Second, secondUnit))
+	}
+
+	if !seconds || isRestricted(src.Minute) {
+		parts = append(parts, describeSegment(src.Minute, minuteUnit))
+	}
+
+	if isRestricted(src.Hour) {
+		parts = append(parts, describeSegment(src.Hour, hourUnit))
+	}
+
+	return strings.Join(parts, " past ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// describeSegment describes each item of a segment (ex every minute
+// from 1 through 5), joining the single values into one list
+func describeSegment(seg string, u unit) string {
+	seg = strings.ToUpper(seg)
+	if u.replacer != nil {
+		seg = u.replacer.Replace(seg)
+	}
+
+	if !isRestricted(seg) {
+		return "every " + u.name
+	}
+
+	stepRegex := regexp.MustCompile(`^(?:\*|(\d+)-(\d+))/(\d+)$`)
+	rangeRegex := regexp.MustCompile(`^(\d+)-(\d+)$`)
+
+	var items, values []string
+
+	// seen holds the single values described, as two numbers can be
+	// the same value (ex 0 and 7 are both Sunday)
+	seen := map[string]bool{}
+
+	// Adds the single values seen so far as one item
+	flush := func() {
+		if len(values) == 0 {
+			return
+		}
+
+		if u.prefix {
+			items = append(items, u.name+" "+joinList(values))
+		} else {
+			items = append(items, joinList(values))
+		}
+		values = nil
+	}
+
+	for _, item := range strings.Split(seg, ",") {
+		if item == "" {
+			continue
+		}
+
+		// Quartz rules
+		if u.rules != nil {
+			if rest, rules, err := u.rules(item); err == nil {
+				if len(rules) > 0 {
+					flush()
+					items = append(items, describeRule(rules[0]))
+					continue
+				}
+				item = rest
+			}
+		}
+
+		// Single value
+		if num, err := strconv.Atoi(item); err == nil {
+			if value := u.describeValue(num); !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+			continue
+		}
+
+		flush()
+
+		// Wildcard
+		if item == "*" {
+			items = append(items, "every "+u.name)
+			continue
+		}
+
+		// Step
+		if match := stepRegex.FindStringSubmatch(item); match != nil {
+			step, _ := strconv.Atoi(match[3])
+			desc := fmt.Sprintf("every %s %s", ordinal(step), u.name)
+
+			if match[1] != "" {
+				start, _ := strconv.Atoi(match[1])
+				end, _ := strconv.Atoi(match[2])
+				desc += fmt.Sprintf(" from %s through %s", u.describeValue(start), u.describeValue(end))
+			}
+
+			items = append(items, desc)
+			continue
+		}
+
+		// Range
+		if match := rangeRegex.FindStringSubmatch(item); match != nil {
+			start, _ := strconv.Atoi(match[1])
+			end, _ := strconv.Atoi(match[2])
+			items = append(items, fmt.Sprintf("every %s from %s through %s", u.name, u.describeValue(start), u.describeValue(end)))
+			continue
+		}
+
+		// Anything else is given as written
+		items = append(items, item)
+	}
+
+	flush()
+
+	return joinList(items)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// describeValue returns the name of a value
+func (u unit) describeValue(n int) string {
+	if u.value == nil {
+		return strconv.Itoa(n)
+	}
+	return u.value(n)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// describeRule describes a Quartz day rule (ex the third Friday of
+// the month)
+func describeRule(r DayRule) string {
+	switch r.Kind {
+	case LastDayOfMonth:
+		if r.N == 1 {
+			return "1 day before the last day of the month"
+		} else if r.N > 1 {
+			return fmt.Sprintf("%d days before the last day of the month", r.N)
+		}
+		return "the last day of the month"
+	case LastWeekdayOfMonth:
+		return "the last weekday of the month"
+	case NearestWeekday:
+		return fmt.Sprintf("the weekday nearest day-of-month %d", r.Day)
+	case LastDayOfWeek:
+		return fmt.Sprintf("the last %s of the month", dayNames[r.Day])
+	case NthDayOfWeek:
+		return fmt.Sprintf("the %s %s of the month", nthNames[r.N], dayNames[r.Day])
+	}
+	return r.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// isRestricted checks if a segment limits its field, rather than
+// being empty, * or ?
+func isRestricted(seg string) bool {
+	return seg != "" && seg != "*" && seg != "?"
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ordinal returns a number with its ordinal suffix (ex 1st, 2nd, 11th)
+func ordinal(n int) string {
+	suffix := "th"
+
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// joinList joins items into an English list (ex 1, 2, and 3)
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	}
+
+	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
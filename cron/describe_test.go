@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Describe_Expression(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		dialect  Dialect
+		expected string
+	}{
+		{"Every_Minute", "* * * * * /cmd", DialectAuto, "At every minute."},
+		{"Time", "30 9 * * * /cmd", DialectAuto, "At 09:30."},
+		{"Steps_Lists_Ranges", "*/15 0 1,15 * 1-5 /cmd", DialectAuto, "At every 15th minute past hour 0 on day-of-month 1 and 15 or on every day-of-week from Monday through Friday."},
+		{"Star_Step_Days", "0 0 */2 * MON /cmd", DialectAuto, "At 00:00 on every 2nd day-of-month and on Monday."},
+		{"List", "1,2,3 * * * * /cmd", DialectAuto, "At minute 1, 2, and 3."},
+		{"Range_Step", "0-30/5 9-17 * * * /cmd", DialectAuto, "At every 5th minute from 0 through 30 past every hour from 9 through 17."},
+		{"Mixed", "0 1,2-4,*/12 * * * /cmd", DialectAuto, "At minute 0 past hour 1, every hour from 2 through 4, and every 12th hour."},
+		{"Ordinals", "*/2 */3 */21 * * /cmd", DialectAuto, "At every 2nd minute past every 3rd hour on every 21st day-of-month."},
+		{"Month_Names", "0 0 1 jan,JUL * /cmd", DialectAuto, "At 00:00 on day-of-month 1 in January and July."},
+		{"Month_Range", "0 0 * 3-5 * /cmd", DialectAuto, "At 00:00 in every month from March through May."},
+		{"Day_Names", "0 9 * * SAT,SUN /cmd", DialectAuto, "At 09:00 on Saturday and Sunday."},
+		{"Sunday_7", "0 9 * * 7 /cmd", DialectAuto, "At 09:00 on Sunday."},
+		{"Sunday_0_7", "0 0 * * 0,7 /cmd", DialectAuto, "At 00:00 on Sunday."},
+		{"Duplicate_Names", "0 0 * * 1,MON /cmd", DialectAuto, "At 00:00 on Monday."},
+		{"Macro", "@daily /cmd", DialectAuto, "At 00:00."},
+		{"Time_Zone", "CRON_TZ=Europe/London 0 9 * * MON /cmd", DialectAuto, "At 09:00 on Monday (Europe/London)."},
+		{"Reboot", "@reboot /cmd", DialectAuto, "At reboot."},
+		{"Every", "@every 1h30m /cmd", DialectAuto, "Every 1h30m0s."},
+		{"Seconds", "*/10 * * * * *", DialectSeconds, "At every 10th second."},
+		{"Seconds_Time", "30 15 10 * * *", DialectSeconds, "At 10:15:30."},
+		{"Seconds_Minute", "0 */5 * * * *", DialectSeconds, "At every 5th minute."},
+		{"Quartz_Days", "0 0 12 ? * MON-FRI", DialectQuartz, "At 12:00 on every day-of-week from Monday through Friday."},
+		{"Quartz_Year", "0 0 12 1 * ? 2030-2032", DialectQuartz, "At 12:00 on day-of-month 1 in every year from 2030 through 2032."},
+		{"Quartz_Last", "0 0 12 L * ?", DialectQuartz, "At 12:00 on the last day of the month."},
+		{"Quartz_Last_Offset", "0 0 12 L-3 * ?", DialectQuartz, "At 12:00 on 3 days before the last day of the month."},
+		{"Quartz_Last_Weekday", "0 0 12 LW * ?", DialectQuartz, "At 12:00 on the last weekday of the month."},
+		{"Quartz_Nearest_Weekday", "0 0 12 15W * ?", DialectQuartz, "At 12:00 on the weekday nearest day-of-month 15."},
+		{"Quartz_Last_Day_Of_Week", "0 0 12 ? * 6L", DialectQuartz, "At 12:00 on the last Friday of the month."},
+		{"Quartz_Nth_Day_Of_Week", "0 0 12 ? * FRI#3", DialectQuartz, "At 12:00 on the third Friday of the month."},
+		{"Quartz_Saturday", "0 0 12 ? * L", DialectQuartz, "At 12:00 on Saturday."},
+		{"Quartz_Rules_And_Values", "0 0 12 1,L * ?", DialectQuartz, "At 12:00 on day-of-month 1 and the last day of the month."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cron, err := ParseWithOptions(tc.exp, ParseOptions{Dialect: tc.dialect})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, cron.Describe())
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Both days must match
+	t.Run("Day_Match_And", func(t *testing.T) {
+		cron, err := ParseWithOptions("0 0 1 * MON /cmd", ParseOptions{DayMatch: DayMatchAnd})
+		assert.Nil(t, err)
+		assert.Equal(t, "At 00:00 on day-of-month 1 and on Monday.", cron.Describe())
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Describe_Ordinal(t *testing.T) {
+	testCases := []struct {
+		n        int
+		expected string
+	}{
+		{1, "1st"}, {2, "2nd"}, {3, "3rd"}, {4, "4th"}, {11, "11th"},
+		{12, "12th"}, {13, "13th"}, {21, "21st"}, {22, "22nd"}, {111, "111th"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ordinal(tc.n))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Describe_JoinList(t *testing.T) {
+	assert.Equal(t, "", joinList(nil))
+	assert.Equal(t, "a", joinList([]string{"a"}))
+	assert.Equal(t, "a and b", joinList([]string{"a", "b"}))
+	assert.Equal(t, "a, b, and c", joinList([]string{"a", "b", "c"}))
+}
@@ -0,0 +1,98 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Dialect is the flavour of cron expression being parsed
+type Dialect int
+
+const (
+	// DialectAuto detects the dialect from the expression. Quartz is
+	// picked when its day of month or day of week field is ?, as
+	// those positions never hold ? in the other dialects. Seconds is
+	// picked for exactly 6 parts when the last is a day of week (ex
+	// 0 */5 * * * *), as it can't be read as standard without taking
+	// a time field as the command. Everything else is treated as
+	// standard
+	DialectAuto Dialect = iota
+	// DialectStandard is the 5 field format used by Vixie cron
+	// followed by a command
+	DialectStandard
+	// DialectSeconds adds a leading seconds field, as used by
+	// robfig/cron and Spring. The command is optional
+	DialectSeconds
+	// DialectQuartz adds a leading seconds field and an optional
+	// trailing year field (1970 - 2099). Days of the week are 1 - 7
+	// from Sunday and one of day of month or day of week must be ?.
+	// The command is optional
+	DialectQuartz
+	// DialectSystemd is a systemd OnCalendar spec (ex Mon..Fri 09:00)
+	// with an optional trailing time zone. It is never detected
+	DialectSystemd
+	// DialectAWS is an AWS EventBridge schedule, either cron() with the
+	// Quartz fields less seconds and a required year, or rate() (ex
+	// cron(0 12 * * ? *) or rate(5 minutes)). Schedules run in UTC. It
+	// is detected from cron( or rate(
+	DialectAWS
+)
+
+// dialectNames maps each Dialect to its name
+var dialectNames = map[Dialect]string{
+	DialectAuto:     "auto",
+	DialectStandard: "standard",
+	DialectSeconds:  "seconds",
+	DialectQuartz:   "quartz",
+	DialectSystemd:  "systemd",
+	DialectAWS:      "aws",
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns the name of the dialect
+func (d Dialect) String() string {
+	return dialectNames[d]
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// quartzWeekdays checks if the dialect numbers the days of the week
+// 1 - 7 from Sunday, as Quartz does, rather than 0 - 6
+func (d Dialect) quartzWeekdays() bool {
+	return d == DialectQuartz || d == DialectAWS
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseDialect returns the Dialect with the given name
+func ParseDialect(name string) (Dialect, error) {
+	for d, n := range dialectNames {
+		if strings.EqualFold(n, name) {
+			return d, nil
+		}
+	}
+
+	return DialectAuto, fmt.Errorf("dialect - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// detectDialect picks the dialect of an expression split into parts
+//
+// See DialectAuto
+func detectDialect(parts []string) Dialect {
+	if len(parts) >= 6 && (parts[3] == "?" || parts[5] == "?") {
+		return DialectQuartz
+	}
+
+	if len(parts) == 6 {
+		if _, _, err := parseDayOfWeek(parts[5], false); err == nil {
+			return DialectSeconds
+		}
+	}
+
+	return DialectStandard
+}
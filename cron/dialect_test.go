@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Dialect_ParseDialect(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		expected Dialect
+	}{
+		{"auto", DialectAuto},
+		{"standard", DialectStandard},
+		{"Seconds", DialectSeconds},
+		{"QUARTZ", DialectQuartz},
+		{"systemd", DialectSystemd},
+		{"aws", DialectAWS},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseDialect(tc.name)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, strings.ToLower(tc.name), res.String())
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseDialect("spring")
+		assert.EqualError(t, err, "dialect - unknown - spring")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Dialect_Detect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected Dialect
+	}{
+		{"Standard", "*/15 0 1,15 * 1-5 /usr/bin/find", DialectStandard},
+		{"Standard_No_Specific", "0 0 ? * 1 /cmd", DialectStandard},
+		{"Quartz_Day_Of_Month", "0 0 12 ? * MON", DialectQuartz},
+		{"Quartz_Day_Of_Week", "0 0 12 1 * ? 2030", DialectQuartz},
+		{"Seconds", "0 */5 * * * *", DialectSeconds},
+		{"Seconds_Weekdays", "*/10 * * * * 1-5", DialectSeconds},
+		{"Standard_Command", "0 0 * * * backup", DialectStandard},
+		{"Standard_Long_Command", "0 0 * * * 1-5 /cmd", DialectStandard},
+		{"Too_Short", "0 0 12 ?", DialectStandard},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, detectDialect(strings.Split(tc.input, " ")))
+		})
+	}
+}
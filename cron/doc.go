@@ -0,0 +1,21 @@
+// Package cron parses cron expressions and works out when they run
+//
+// It is the parser used by the visualcron command, so a schedule is
+// valid here exactly when visualcron accepts it
+//
+//	c, err := cron.Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+//	if err != nil {
+//		return err
+//	}
+//
+//	next := c.Next(time.Now())
+//	ok := c.Matches(next)
+//
+// Parse detects the dialect (standard, seconds or Quartz) from the
+// expression, and ParseWithOptions sets the dialect, system crontab
+// user field, day matching and daylight saving time policy. A whole
+// crontab is parsed with ParseCrontab
+//
+// Expressions with a CRON_TZ or TZ prefix run in that time zone, from
+// the time zone database embedded in the package
+package cron
@@ -0,0 +1,334 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DSTPolicy is how runs that fall in a daylight saving time change
+// are handled
+type DSTPolicy int
+
+const (
+	// DSTVixie follows Vixie cron. A job at a fixed time in the hour
+	// skipped when the clocks go forward runs straight after the
+	// change, and a job in the hour repeated when the clocks go back
+	// runs once. Jobs with * in the minute or hour field follow the
+	// wall clock, as with DSTWallClock
+	DSTVixie DSTPolicy = iota
+	// DSTWallClock runs at wall clock times only. A run in a skipped
+	// hour does not happen and a run in a repeated hour happens twice
+	DSTWallClock
+)
+
+// dstPolicyNames maps each DSTPolicy to its name
+var dstPolicyNames = map[DSTPolicy]string{
+	DSTVixie:     "vixie",
+	DSTWallClock: "wall",
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns the name of the policy
+func (p DSTPolicy) String() string {
+	return dstPolicyNames[p]
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseDSTPolicy returns the DSTPolicy with the given name
+func ParseDSTPolicy(name string) (DSTPolicy, error) {
+	for p, n := range dstPolicyNames {
+		if strings.EqualFold(n, name) {
+			return p, nil
+		}
+	}
+
+	return DSTVixie, fmt.Errorf("dst - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// DSTChange is how a daylight saving time change affected a run
+type DSTChange int
+
+const (
+	// DSTNone is a run at its scheduled time
+	DSTNone DSTChange = iota
+	// DSTShifted is a run whose time was skipped when the clocks went
+	// forward, so it runs at the change instead
+	DSTShifted
+	// DSTSkipped is a run whose time was skipped when the clocks went
+	// forward, so it does not run
+	DSTSkipped
+	// DSTRepeated is the second run of a time repeated when the
+	// clocks went back
+	DSTRepeated
+)
+
+// String returns a string representation of DSTChange
+func (d DSTChange) String() string {
+	switch d {
+	case DSTShifted:
+		return "shifted"
+	case DSTSkipped:
+		return "skipped"
+	case DSTRepeated:
+		return "repeated"
+	}
+	return ""
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Occurrence is a single run of a schedule
+//
+// Wall is the wall clock time the run was scheduled for, with its
+// fields in UTC, as it may not exist in the schedule's time zone.
+// Shifted and skipped runs have the Time the clocks went forward
+type Occurrence struct {
+	Time   time.Time
+	Wall   time.Time
+	Change DSTChange
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Runs checks if the job runs for the occurrence
+func (o Occurrence) Runs() bool {
+	return o.Change != DSTSkipped
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// dstWindow is the largest daylight saving time change handled. Wall
+// clock times are searched this far either side of a time near a
+// change
+const dstWindow = 3 * time.Hour
+
+// unlimitedRuns is the n of occurrences that searches up to end, with
+// no limit on the number of runs
+const unlimitedRuns = -1
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// occurrences returns the occurrences after t (or before t when back
+// is set), closest first, up to the nth run or end. An n of
+// unlimitedRuns has no limit and a zero end has no end
+//
+// Wall clock times are searched in order. Around a daylight saving
+// time change wall clock order is not the same as real time, so the
+// search starts and stops dstWindow either side
+func (c Cron) occurrences(t time.Time, n int, back bool, end time.Time) []Occurrence {
+	if n <= 0 && n != unlimitedRuns {
+		return nil
+	}
+
+	t = c.in(t)
+	loc := t.Location()
+
+	// beyond checks if a is further along the search than b
+	beyond := func(a, b time.Time) bool {
+		if back {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	// margin is how far wall clock order can be out of step with real
+	// time around x
+	margin := func(x time.Time) time.Duration {
+		if nearTransition(x.In(loc)) {
+			return dstWindow
+		}
+		return 0
+	}
+
+	// edge returns the wall clock time that candidates must be beyond
+	// to be further along the search than x
+	edge := func(x time.Time) time.Time {
+		if back {
+			return wallClock(x.In(loc)).Add(-margin(x))
+		}
+		return wallClock(x.In(loc)).Add(margin(x))
+	}
+
+	// Start far enough back that no run beyond t is missed
+	w := wallClock(t).Add(-margin(t))
+	if back {
+		w = wallClock(t).Add(margin(t))
+	}
+
+	var found []Occurrence
+
+	for {
+		if back {
+			w = c.prevWall(w)
+		} else {
+			w = c.nextWall(w)
+		}
+
+		if w.IsZero() {
+			break
+		}
+
+		// Stop once every candidate left is beyond the nth run or end
+		limit := end
+		if i := nthRun(found, n); i >= 0 {
+			limit = found[i].Time
+		}
+
+		if !limit.IsZero() && beyond(w, edge(limit)) {
+			break
+		}
+
+		for _, o := range c.expand(w, loc) {
+			if !beyond(o.Time, t) || (!end.IsZero() && beyond(o.Time, end)) {
+				continue
+			}
+
+			// Insert in order, which is only out of step around a change
+			found = append(found, o)
+			for i := len(found) - 1; i > 0 && beyond(found[i-1].Time, found[i].Time); i-- {
+				found[i-1], found[i] = found[i], found[i-1]
+			}
+		}
+	}
+
+	if i := nthRun(found, n); i >= 0 {
+		found = found[:i+1]
+	}
+
+	return found
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// expand returns the occurrences of a matching wall clock time,
+// following the DSTPolicy
+func (c Cron) expand(w time.Time, loc *time.Location) []Occurrence {
+	instants, change := resolveWall(w, loc)
+
+	// Vixie cron moves or drops runs at a fixed time
+	fixed := c.DST == DSTVixie &&
+		!strings.HasPrefix(c.Source.Minute, "*") &&
+		!strings.HasPrefix(c.Source.Hour, "*")
+
+	switch len(instants) {
+	case 0:
+		if fixed {
+			return []Occurrence{{Time: change, Wall: w, Change: DSTShifted}}
+		}
+		return []Occurrence{{Time: change, Wall: w, Change: DSTSkipped}}
+	case 1:
+		return []Occurrence{{Time: instants[0], Wall: w}}
+	}
+
+	if fixed {
+		return []Occurrence{{Time: instants[0], Wall: w}}
+	}
+
+	return []Occurrence{
+		{Time: instants[0], Wall: w},
+		{Time: instants[1], Wall: w, Change: DSTRepeated},
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// resolveWall returns the times in loc that show the wall clock time
+// w, earliest first. There are none when w is skipped by a change, in
+// which case the time of the change is returned too, and two when w is
+// repeated
+func resolveWall(w time.Time, loc *time.Location) ([]time.Time, time.Time) {
+	// The offsets either side of w
+	guess := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+	_, before := guess.Add(-dstWindow).Zone()
+	_, after := guess.Add(dstWindow).Zone()
+
+	var result []time.Time
+	for _, offset := range []int{before, after} {
+		t := w.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClock(t).Equal(w) && (len(result) == 0 || !result[0].Equal(t)) {
+			result = append(result, t)
+		}
+	}
+
+	if len(result) == 2 && result[1].Before(result[0]) {
+		result[0], result[1] = result[1], result[0]
+	}
+
+	if len(result) > 0 {
+		return result, time.Time{}
+	}
+
+	// Skipped, so find the change between the times w would be at
+	// with the offset after and before it
+	lo := w.Add(-time.Duration(after) * time.Second)
+	hi := w.Add(-time.Duration(before) * time.Second)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, offset := mid.In(loc).Zone(); offset == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return nil, hi.Truncate(time.Second).In(loc)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// wallClock returns the wall clock time of t, with its fields in UTC
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// nearTransition checks if the offset of t's location changes within
+// dstWindow of t
+func nearTransition(t time.Time) bool {
+	_, before := t.Add(-dstWindow).Zone()
+	_, after := t.Add(dstWindow).Zone()
+	return before != after
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// nthRun returns the index of the nth occurrence that runs, or -1 when
+// there are fewer or n is unlimitedRuns
+func nthRun(occurrences []Occurrence, n int) int {
+	if n == unlimitedRuns {
+		return -1
+	}
+
+	runs := 0
+	for i, o := range occurrences {
+		if o.Runs() {
+			runs++
+			if runs == n {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// runTimes returns the times of the occurrences that run
+func runTimes(occurrences []Occurrence) []time.Time {
+	var result []time.Time
+	for _, o := range occurrences {
+		if o.Runs() {
+			result = append(result, o.Time)
+		}
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// london is the zone used for the daylight saving time tests. The
+// clocks went forward at 01:00 UTC on 2022-03-27 and back at 01:00
+// UTC on 2022-10-30
+var london, _ = ParseLocation("Europe/London")
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DST_ParseDSTPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected DSTPolicy
+	}{
+		{"vixie", DSTVixie},
+		{"wall", DSTWallClock},
+		{"WALL", DSTWallClock},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParseDSTPolicy(tc.name)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Unknown policy
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParseDSTPolicy("utc")
+		assert.EqualError(t, err, "dst - unknown - utc")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DST_ResolveWall(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A normal time has one instant
+	t.Run("Normal", func(t *testing.T) {
+		instants, change := resolveWall(date(2022, 6, 1, 1, 30), london)
+		assert.Equal(t, []time.Time{date(2022, 6, 1, 0, 30).In(london)}, instants)
+		assert.True(t, change.IsZero())
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A skipped time has none, and the change is returned
+	t.Run("Gap", func(t *testing.T) {
+		instants, change := resolveWall(date(2022, 3, 27, 1, 30), london)
+		assert.Empty(t, instants)
+		assert.True(t, date(2022, 3, 27, 1, 0).Equal(change))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A repeated time has two, earliest first
+	t.Run("Overlap", func(t *testing.T) {
+		instants, _ := resolveWall(date(2022, 10, 30, 1, 30), london)
+		assert.Len(t, instants, 2)
+		assert.True(t, date(2022, 10, 30, 0, 30).Equal(instants[0]))
+		assert.True(t, date(2022, 10, 30, 1, 30).Equal(instants[1]))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_DST_Occurrences(t *testing.T) {
+	// occurrence is a shorthand for an Occurrence at a UTC time
+	type occurrence struct {
+		time   time.Time
+		change DSTChange
+	}
+
+	testCases := []struct {
+		name     string
+		exp      string
+		policy   DSTPolicy
+		from     time.Time
+		expected []occurrence
+	}{
+		{"Vixie_Gap_Fixed", "30 1 * * *", DSTVixie, date(2022, 3, 26, 12, 0), []occurrence{
+			{date(2022, 3, 27, 1, 0), DSTShifted},
+			{date(2022, 3, 28, 0, 30), DSTNone},
+		}},
+		{"Wall_Gap_Fixed", "30 1 * * *", DSTWallClock, date(2022, 3, 26, 12, 0), []occurrence{
+			{date(2022, 3, 27, 1, 0), DSTSkipped},
+			{date(2022, 3, 28, 0, 30), DSTNone},
+			{date(2022, 3, 29, 0, 30), DSTNone},
+		}},
+		{"Vixie_Gap_Wildcard", "*/30 1 * * *", DSTVixie, date(2022, 3, 26, 12, 0), []occurrence{
+			{date(2022, 3, 27, 1, 0), DSTSkipped},
+			{date(2022, 3, 27, 1, 0), DSTSkipped},
+			{date(2022, 3, 28, 0, 0), DSTNone},
+			{date(2022, 3, 28, 0, 30), DSTNone},
+		}},
+		{"Vixie_Overlap_Fixed", "30 1 * * *", DSTVixie, date(2022, 10, 29, 12, 0), []occurrence{
+			{date(2022, 10, 30, 0, 30), DSTNone},
+			{date(2022, 10, 31, 1, 30), DSTNone},
+		}},
+		{"Wall_Overlap_Fixed", "30 1 * * *", DSTWallClock, date(2022, 10, 29, 12, 0), []occurrence{
+			{date(2022, 10, 30, 0, 30), DSTNone},
+			{date(2022, 10, 30, 1, 30), DSTRepeated},
+		}},
+		{"Vixie_Overlap_Wildcard", "*/30 1 * * *", DSTVixie, date(2022, 10, 29, 12, 0), []occurrence{
+			{date(2022, 10, 30, 0, 0), DSTNone},
+			{date(2022, 10, 30, 0, 30), DSTNone},
+			{date(2022, 10, 30, 1, 0), DSTRepeated},
+			{date(2022, 10, 30, 1, 30), DSTRepeated},
+		}},
+		{"Hourly_Overlap", "0 * * * *", DSTVixie, date(2022, 10, 29, 23, 30), []occurrence{
+			{date(2022, 10, 30, 0, 0), DSTNone},
+			{date(2022, 10, 30, 1, 0), DSTRepeated},
+			{date(2022, 10, 30, 2, 0), DSTNone},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions("CRON_TZ=Europe/London "+tc.exp+" /cmd", ParseOptions{DST: tc.policy})
+			assert.Nil(t, err)
+
+			runs := 0
+			for _, o := range tc.expected {
+				if o.change != DSTSkipped {
+					runs++
+				}
+			}
+
+			result := c.NextOccurrences(tc.from, runs)
+			assert.Len(t, result, len(tc.expected))
+			for i := 0; i < len(result) && i < len(tc.expected); i++ {
+				assert.True(t, tc.expected[i].time.Equal(result[i].Time), "%d: %s", i, result[i].Time)
+				assert.Equal(t, tc.expected[i].change, result[i].Change, "%d", i)
+			}
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Searching back returns the repeated run first
+	t.Run("Prev_Overlap", func(t *testing.T) {
+		c, err := ParseWithOptions("CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{DST: DSTWallClock})
+		assert.Nil(t, err)
+
+		result := c.PrevOccurrences(date(2022, 10, 30, 3, 0), 3)
+		assert.Len(t, result, 3)
+		assert.True(t, date(2022, 10, 30, 1, 30).Equal(result[0].Time))
+		assert.Equal(t, DSTRepeated, result[0].Change)
+		assert.True(t, date(2022, 10, 30, 0, 30).Equal(result[1].Time))
+		assert.True(t, date(2022, 10, 29, 0, 30).Equal(result[2].Time))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Skipped runs are not run times
+	t.Run("Between_Gap", func(t *testing.T) {
+		c, err := ParseWithOptions("CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{DST: DSTWallClock})
+		assert.Nil(t, err)
+
+		result := c.OccurrencesBetween(date(2022, 3, 26, 0, 0), date(2022, 3, 28, 0, 30))
+		assert.Len(t, result, 3)
+		assert.Equal(t, DSTSkipped, result[1].Change)
+		assert.Equal(t, []time.Time{
+			date(2022, 3, 26, 1, 30).In(london),
+			date(2022, 3, 28, 0, 30).In(london),
+		}, c.Between(date(2022, 3, 26, 0, 0), date(2022, 3, 28, 0, 30)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Next and Prev only return runs
+	t.Run("Next_Prev", func(t *testing.T) {
+		c, err := ParseWithOptions("CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{DST: DSTWallClock})
+		assert.Nil(t, err)
+		assert.True(t, date(2022, 3, 28, 0, 30).Equal(c.Next(date(2022, 3, 26, 12, 0))))
+		assert.True(t, date(2022, 3, 26, 1, 30).Equal(c.Prev(date(2022, 3, 28, 0, 0))))
+	})
+}
@@ -0,0 +1,310 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseError is an error in a cron expression, pointing at the part
+// of the expression that could not be parsed
+//
+// Field is the field the error is in (ex minute, day of week), or the
+// part of the expression when it is not a field (ex macro, time zone).
+// It is empty when the expression is too short. Column is the byte
+// offset of Token in Expression, which is the end of the expression
+// when something is missing. Hint suggests a fix
+type ParseError struct {
+	Expression string
+	Field      string
+	Column     int
+	Token      string
+	Hint       string
+	Err        error
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Error returns the error message, without the position or hint
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("parsing error - %s - %s", e.Field, e.Err)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseErrors is every error found in an expression, in the order
+// they appear. It is returned instead of a single ParseError when
+// there is more than one unrecognised token
+type ParseErrors []*ParseError
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Error returns the error messages, separated by semicolons
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, pe := range e {
+		messages[i] = pe.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// err returns nil when there are no errors, the ParseError when there
+// is one and the ParseErrors otherwise
+func (e ParseErrors) err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// The underlying errors of a ParseError
+var (
+	errNotEnoughParts = errors.New("not enough parts in the cron expression")
+	errEmpty          = errors.New("empty")
+	errInvalid        = errors.New("invalid")
+	errStepInvalid    = errors.New("step - invalid")
+	errStepTooBig     = errors.New("step - step is too big")
+	errStepZero       = errors.New("step - step is zero")
+	errRangeInvalid   = errors.New("range - invalid")
+	errLastInvalid    = errors.New("L - invalid")
+	errWeekdayInvalid = errors.New("W - invalid")
+	errNthInvalid     = errors.New("# - invalid")
+	errUnrecognised   = errors.New("unrecognised")
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// tokenError is an error in one item of a field (ex 70 in 1,70),
+// after any names in the field were replaced
+type tokenError struct {
+	token string
+	err   error
+}
+
+func (e tokenError) Error() string {
+	return e.err.Error()
+}
+
+func (e tokenError) Unwrap() error {
+	return e.err
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// unrecognisedError holds the items of a field that are not a number,
+// range, step or rule, after any names in the field were replaced
+type unrecognisedError struct {
+	tokens []string
+}
+
+func (e unrecognisedError) Error() string {
+	return fmt.Sprintf("%s - %s", errUnrecognised, strings.Join(e.tokens, ","))
+}
+
+// isUnrecognised checks if err is an unrecognisedError
+func isUnrecognised(err error) bool {
+	var ue unrecognisedError
+	return errors.As(err, &ue)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// fieldValues are the values each field accepts, for hints
+var fieldValues = map[string]string{
+	"second":       "0-59",
+	"minute":       "0-59",
+	"hour":         "0-23",
+	"day of month": "1-31, L, LW or a day followed by W",
+	"month":        "1-12 or JAN-DEC",
+	"day of week":  "0-7 or SUN-SAT",
+	"year":         "1970-2099",
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// field describes a field of an expression for fieldError
+type field struct {
+	name string
+	// start is the byte offset of the field in the expression
+	start int
+	// text is the field as it was written
+	text string
+	// normalise turns an item as written into the form it was
+	// parsed in, with names replaced (ex mon to 1)
+	normalise func(string) string
+	quartz    bool
+	// seconds is set when a standard expression has a 6th time field,
+	// so it likely starts with seconds (ex 0 30 9 * * MON-FRI)
+	seconds bool
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// fieldError returns a ParseError for a field that failed to parse.
+// It points at the item that failed when that is known, otherwise at
+// the whole field
+func fieldError(exp string, f field, err error) *ParseError {
+	pe := &ParseError{
+		Expression: exp,
+		Field:      f.name,
+		Column:     f.start,
+		Token:      f.text,
+		Hint:       fieldHint(f, err),
+		Err:        err,
+	}
+
+	var te tokenError
+	if !errors.As(err, &te) {
+		return pe
+	}
+
+	// Find the item as it was written
+	offset := 0
+	for _, item := range strings.Split(f.text, ",") {
+		if f.normalise(item) == te.token {
+			pe.Column = f.start + offset
+			pe.Token = item
+			break
+		}
+		offset += len(item) + 1
+	}
+
+	return pe
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// unrecognisedErrors returns a ParseError for each unrecognised token
+// in a field, pointing at the items as they were written
+func unrecognisedErrors(exp string, f field, tokens []string) ParseErrors {
+	var result ParseErrors
+
+	offset := 0
+	for _, item := range strings.Split(f.text, ",") {
+		if len(result) < len(tokens) && f.normalise(item) == tokens[len(result)] {
+			err := fmt.Errorf("%w - %s", errUnrecognised, item)
+			result = append(result, &ParseError{
+				Expression: exp,
+				Field:      f.name,
+				Column:     f.start + offset,
+				Token:      item,
+				Hint:       fieldHint(f, err),
+				Err:        err,
+			})
+		}
+		offset += len(item) + 1
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// fieldHint suggests a fix for an error in a field
+func fieldHint(f field, err error) string {
+	if f.seconds {
+		f.seconds = false
+		return fieldHint(f, err) + ", or use -dialect seconds when the first of 6 time fields is seconds"
+	}
+
+	values := fieldValues[f.name]
+	if f.name == "day of week" && f.quartz {
+		values = "1-7 or SUN-SAT"
+	}
+
+	switch {
+	case errors.Is(err, errStepTooBig):
+		return "the step must be smaller than the largest " + f.name
+	case errors.Is(err, errStepZero):
+		return "the step must be at least 1"
+	case errors.Is(err, errStepInvalid):
+		return "a step must be */n or a range followed by /n (ex 0-30/5)"
+	case errors.Is(err, errRangeInvalid):
+		return fmt.Sprintf("a range must go from low to high, within %s", values)
+	case errors.Is(err, errLastInvalid) && f.name == "day of month":
+		return "L can be followed by an offset of up to 30 days (ex L-3)"
+	case errors.Is(err, errLastInvalid):
+		return fmt.Sprintf("L must follow a day of the week (ex 5L), within %s", values)
+	case errors.Is(err, errWeekdayInvalid):
+		return "W must follow a day of the month, within 1-31 (ex 15W)"
+	case errors.Is(err, errUnrecognised):
+		return fmt.Sprintf("use %s, a range (ex 1-5) or a step (ex */15), separated by commas", values)
+	case errors.Is(err, errNthInvalid):
+		return fmt.Sprintf("# must be between a day of the week within %s and a week of 1-5 (ex 5#3)", values)
+	}
+
+	return fmt.Sprintf("%s must be %s", f.name, values)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// missingError returns a ParseError for an expression that stops
+// before a field it needs, pointing at its end
+func missingError(exp, hint string) *ParseError {
+	return &ParseError{
+		Expression: exp,
+		Column:     len(strings.TrimRightFunc(exp, unicode.IsSpace)),
+		Hint:       hint,
+		Err:        errNotEnoughParts,
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// timeZoneError returns a ParseError for an unknown time zone name at
+// column
+func timeZoneError(exp string, column int, name string) *ParseError {
+	return &ParseError{
+		Expression: exp,
+		Field:      "time zone",
+		Column:     column,
+		Token:      name,
+		Hint:       "use an IANA time zone name (ex Europe/London)",
+		Err:        fmt.Errorf("invalid - %s", name),
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// fieldOffsets returns the byte offset of each whitespace separated
+// field of an expression, matching strings.Fields
+func fieldOffsets(exp string) []int {
+	var (
+		offsets []int
+		inField bool
+	)
+
+	for i, r := range exp {
+		if unicode.IsSpace(r) {
+			inField = false
+			continue
+		}
+
+		if !inField {
+			offsets = append(offsets, i)
+			inField = true
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Errors_ParseError(t *testing.T) {
+	testCases := []struct {
+		name    string
+		exp     string
+		dialect Dialect
+		field   string
+		column  int
+		token   string
+		hint    string
+	}{
+		{"Minute", "61 2 * * * /cmd", DialectAuto, "minute", 0, "61", "minute must be 0-59"},
+		{"List_Item", "0 1,2,30 * * * /cmd", DialectAuto, "hour", 6, "30", "hour must be 0-23"},
+		{"Range", "0 0 * 10-2 * /cmd", DialectAuto, "month", 6, "10-2", "a range must go from low to high, within 1-12 or JAN-DEC"},
+		{"Names", "0 0 * * mon,FRI-mon /cmd", DialectAuto, "day of week", 12, "FRI-mon", "a range must go from low to high, within 0-7 or SUN-SAT"},
+		{"Step", "*/75 * * * * /cmd", DialectAuto, "minute", 0, "*/75", "the step must be smaller than the largest minute"},
+		{"Step_Zero", "0 0 1,*/0 * * /cmd", DialectAuto, "day of month", 6, "*/0", "the step must be at least 1"},
+		{"Step_Range", "0 0 1,5-1/2 * * /cmd", DialectAuto, "day of month", 6, "5-1/2", "a range must go from low to high, within 1-31, L, LW or a day followed by W"},
+		{"Spacing", "0  0\t32 * * /cmd", DialectAuto, "day of month", 5, "32", "day of month must be 1-31, L, LW or a day followed by W"},
+		{"Rule", "0 0 1,L-40 * * /cmd", DialectAuto, "day of month", 6, "L-40", "L can be followed by an offset of up to 30 days (ex L-3)"},
+		{"Nth", "0 0 12 ? * MON#6", DialectQuartz, "day of week", 11, "MON#6", "# must be between a day of the week within 1-7 or SUN-SAT and a week of 1-5 (ex 5#3)"},
+		{"Second", "0 0 60 * * *", DialectSeconds, "hour", 4, "60", "hour must be 0-23"},
+		{"Seconds_First", "0 30 9 * * MON-FRI /cmd", DialectAuto, "hour", 2, "30", "hour must be 0-23, or use -dialect seconds when the first of 6 time fields is seconds"},
+		{"Seconds_First_Standard", "0 0 61 * * 1-5 * /cmd", DialectStandard, "day of month", 4, "61", "day of month must be 1-31, L, LW or a day followed by W, or use -dialect seconds when the first of 6 time fields is seconds"},
+		{"Year", "0 0 12 ? * MON 1969", DialectQuartz, "year", 15, "1969", "year must be 1970-2099"},
+		{"Quartz", "0 0 12 * * MON", DialectQuartz, "quartz", 7, "*", "use ? in one of day of month or day of week (ex 0 0 12 ? * MON)"},
+		{"Not_Enough_Parts", "0 0 * * ", DialectAuto, "", 7, "", "expected 5 time fields followed by a command"},
+		{"Time_Zone", "CRON_TZ=Mars/Olympus 0 0 * * * /cmd", DialectAuto, "time zone", 8, "Mars/Olympus", "use an IANA time zone name (ex Europe/London)"},
+		{"Time_Zone_Field", "TZ=UTC 0 25 * * * /cmd", DialectAuto, "hour", 9, "25", "hour must be 0-23"},
+		{"Macro", "@fortnightly /cmd", DialectAuto, "macro", 0, "@fortnightly", "use one of @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly, @reboot or @every"},
+		{"Every", "@every 1.5s /cmd", DialectAuto, "every", 7, "1.5s", "use a whole number of seconds, at least 1s (ex 90s or 1h30m)"},
+		{"Macro_Command", "@daily", DialectAuto, "", 6, "", "expected a command after @daily"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseWithOptions(tc.exp, ParseOptions{Dialect: tc.dialect})
+
+			var pe *ParseError
+			assert.True(t, errors.As(err, &pe))
+			assert.Equal(t, tc.exp, pe.Expression)
+			assert.Equal(t, tc.field, pe.Field)
+			assert.Equal(t, tc.column, pe.Column)
+			assert.Equal(t, tc.token, pe.Token)
+			assert.Equal(t, tc.hint, pe.Hint)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// The underlying error is kept
+	t.Run("Unwrap", func(t *testing.T) {
+		_, err := Parse("*/75 * * * * /cmd")
+		assert.True(t, errors.Is(err, errStepTooBig))
+		assert.EqualError(t, err, "parsing error - minute - step - step is too big")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Errors_FieldOffsets(t *testing.T) {
+	assert.Empty(t, fieldOffsets(""))
+	assert.Empty(t, fieldOffsets("   "))
+	assert.Equal(t, []int{0, 2, 5}, fieldOffsets("a bb\tccc"))
+	assert.Equal(t, []int{2, 6}, fieldOffsets("  ab  c "))
+}
@@ -0,0 +1,236 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// heatmapBlocks shade a cell by how many runs it has, from a quarter
+// of the busiest cell up to all of it
+var heatmapBlocks = []string{"░", "▒", "▓", "█"}
+
+// heatmapColours are the ANSI colours of each block
+var heatmapColours = []string{"32", "33", "31", "1;31"}
+
+// heatmapEmpty marks a cell without runs
+const heatmapEmpty = "·"
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// HeatmapOptions changes how a heatmap is drawn
+//
+// Bucket is the number of minutes in a cell, which must divide an
+// hour (default 1). Colour adds ANSI colours to the blocks
+type HeatmapOptions struct {
+	Bucket int
+	Colour bool
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// MinuteCounts returns how many times the schedule fires in each
+// minute of a day it runs on, by hour and minute
+//
+// Only time schedules are counted, as @reboot and @every have no
+// fixed run times
+func (c Cron) MinuteCounts() [24][60]int {
+	var counts [24][60]int
+	if c.Kind != KindTime {
+		return counts
+	}
+
+	seconds, _ := c.seconds()
+	for _, hour := range c.Hour {
+		for _, minute := range c.Minute {
+			counts[hour][minute] = len(seconds)
+		}
+	}
+
+	return counts
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Heatmap returns a grid of when the schedules fire within a day,
+// with a row for each hour and a cell for each minute (or bucket of
+// minutes), overlaid so busy times stand out. Each row ends with its
+// number of runs, and a legend lists the schedules and the busiest
+// cell
+//
+// Days are not taken into account, so a schedule that only runs on
+// Mondays adds to the same cells as one that runs every day
+func Heatmap(crons []*Cron, opts HeatmapOptions) (string, error) {
+	bucket := opts.Bucket
+	if bucket == 0 {
+		bucket = 1
+	}
+	if bucket < 0 || bucket > 60 || 60%bucket != 0 {
+		return "", fmt.Errorf("heatmap - bucket must divide an hour - %d", bucket)
+	}
+
+	// Runs in each cell, and which schedules they are from
+	columns := 60 / bucket
+	counts := make([][]int, 24)
+	from := make([][][]int, 24)
+	for hour := range counts {
+		counts[hour] = make([]int, columns)
+		from[hour] = make([][]int, columns)
+	}
+
+	for i, c := range crons {
+		minutes := c.MinuteCounts()
+		for hour := range minutes {
+			for minute, n := range minutes[hour] {
+				if n == 0 {
+					continue
+				}
+
+				column := minute / bucket
+				if len(from[hour][column]) == 0 || from[hour][column][len(from[hour][column])-1] != i+1 {
+					from[hour][column] = append(from[hour][column], i+1)
+				}
+				counts[hour][column] += n
+			}
+		}
+	}
+
+	busiest, busiestHour, busiestColumn := 0, 0, 0
+	for hour := range counts {
+		for column, n := range counts[hour] {
+			if n > busiest {
+				busiest, busiestHour, busiestColumn = n, hour, column
+			}
+		}
+	}
+
+	// Cells are widened so the minute labels fit
+	width := 1
+	if bucket > 1 {
+		width = 2
+	}
+
+	var sb strings.Builder
+	sb.WriteString(heatmapHeader(bucket, width) + "\n")
+
+	for hour := range counts {
+		total := 0
+		fmt.Fprintf(&sb, "%02d │", hour)
+		for _, n := range counts[hour] {
+			total += n
+			sb.WriteString(strings.Repeat(heatmapCell(n, busiest, opts.Colour), width))
+		}
+		fmt.Fprintf(&sb, "│ %d\n", total)
+	}
+
+	// Legend
+	sb.WriteString("\n")
+	if busiest == 0 {
+		sb.WriteString("no runs\n")
+	} else {
+		sb.WriteString(heatmapScale(busiest, bucket, opts.Colour) + "\n")
+	}
+
+	for i, c := range crons {
+		fmt.Fprintf(&sb, "%d  %s\n", i+1, c.Original)
+	}
+
+	if busiest > 0 {
+		ids := make([]string, len(from[busiestHour][busiestColumn]))
+		for i, id := range from[busiestHour][busiestColumn] {
+			ids[i] = fmt.Sprint(id)
+		}
+
+		start := busiestColumn * bucket
+		at := fmt.Sprintf("%02d:%02d", busiestHour, start)
+		if bucket > 1 {
+			at += fmt.Sprintf("-%02d:%02d", busiestHour, start+bucket-1)
+		}
+		fmt.Fprintf(&sb, "busiest %s, %s from %s\n", at, plural(busiest, "run"), strings.Join(ids, ", "))
+	}
+
+	return sb.String(), nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// heatmapHeader returns the minute labels above the grid. Labels are
+// spaced so they don't run into each other (ex every 10 minutes, or
+// every 20 for buckets of 4 minutes)
+func heatmapHeader(bucket, width int) string {
+	every := 60
+	for _, minutes := range []int{10, 15, 20, 30} {
+		if minutes%bucket == 0 && minutes/bucket*width >= 3 {
+			every = minutes
+			break
+		}
+	}
+
+	header := []byte(strings.Repeat(" ", 4+60/bucket*width))
+	for minute := 0; minute < 60; minute += every {
+		copy(header[4+minute/bucket*width:], fmt.Sprint(minute))
+	}
+
+	return strings.TrimRight(string(header), " ")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// heatmapLevel returns the block for n runs, as an index into
+// heatmapBlocks, when the busiest cell has busiest runs
+func heatmapLevel(n, busiest int) int {
+	return (n*len(heatmapBlocks) - 1) / busiest
+}
+
+// heatmapCell returns the block for a cell with n runs
+func heatmapCell(n, busiest int, colour bool) string {
+	if n == 0 {
+		return heatmapEmpty
+	}
+
+	level := heatmapLevel(n, busiest)
+	if colour {
+		return "\x1b[" + heatmapColours[level] + "m" + heatmapBlocks[level] + "\x1b[0m"
+	}
+	return heatmapBlocks[level]
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// heatmapScale returns the range of runs each block stands for (ex
+// ░ 1  ▒ 2  ▓ 3  █ 4-5 runs a minute)
+func heatmapScale(busiest, bucket int, colour bool) string {
+	var parts []string
+
+	low := 1
+	for level := range heatmapBlocks {
+		high := low
+		for high < busiest && heatmapLevel(high+1, busiest) == level {
+			high++
+		}
+		if heatmapLevel(low, busiest) != level {
+			continue
+		}
+
+		block := heatmapCell(low, busiest, colour)
+		if low == high {
+			parts = append(parts, fmt.Sprintf("%s %d", block, low))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d-%d", block, low, high))
+		}
+		low = high + 1
+	}
+
+	unit := "a minute"
+	if bucket > 1 {
+		unit = fmt.Sprintf("in %d minutes", bucket)
+	}
+
+	runs := "runs"
+	if busiest == 1 {
+		runs = "run"
+	}
+
+	return strings.Join(parts, "  ") + " " + runs + " " + unit
+}
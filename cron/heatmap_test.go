@@ -0,0 +1,130 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Heatmap_MinuteCounts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		expected map[[2]int]int
+	}{
+		{"Once", "30 9 * * * /cmd", map[[2]int]int{{9, 30}: 1}},
+		{"Several", "0,30 9,17 * * 1-5 /cmd", map[[2]int]int{{9, 0}: 1, {9, 30}: 1, {17, 0}: 1, {17, 30}: 1}},
+		{"Seconds", "*/15 0 12 * * ?", map[[2]int]int{{12, 0}: 4}},
+		{"Reboot", "@reboot /cmd", map[[2]int]int{}},
+		{"Every", "@every 1m /cmd", map[[2]int]int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			counts := c.MinuteCounts()
+			for hour := range counts {
+				for minute, n := range counts[hour] {
+					assert.Equal(t, tc.expected[[2]int{hour, minute}], n, "%02d:%02d", hour, minute)
+				}
+			}
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Heatmap_Heatmap(t *testing.T) {
+	parse := func(exps ...string) []*Cron {
+		var crons []*Cron
+		for _, exp := range exps {
+			c, err := Parse(exp)
+			assert.Nil(t, err)
+			crons = append(crons, c)
+		}
+		return crons
+	}
+
+	// Rows without runs
+	empty := func(from, to int) string {
+		var sb strings.Builder
+		for hour := from; hour <= to; hour++ {
+			sb.WriteString(fmt.Sprintf("%02d │····│ 0\n", hour))
+		}
+		return sb.String()
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Overlaid, with a legend
+	t.Run("Overlay", func(t *testing.T) {
+		res, err := Heatmap(parse("0 9 * * * /a", "*/20 9 * * * /b"), HeatmapOptions{Bucket: 30})
+		assert.Nil(t, err)
+		assert.Equal(t, "    0\n"+empty(0, 8)+"09 │██▒▒│ 4\n"+empty(10, 23)+`
+▒ 1  ▓ 2  █ 3 runs in 30 minutes
+1  0 9 * * * /a
+2  */20 9 * * * /b
+busiest 09:00-09:29, 3 runs from 1, 2
+`, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A cell for every minute
+	t.Run("Minutes", func(t *testing.T) {
+		res, err := Heatmap(parse("*/15 9-17 * * * /cmd"), HeatmapOptions{})
+		assert.Nil(t, err)
+
+		lines := strings.Split(res, "\n")
+		assert.Equal(t, "    0         10        20        30        40        50", lines[0])
+		assert.Equal(t, "09 │█··············█··············█··············█··············│ 4", lines[10])
+		assert.Contains(t, res, "\n█ 1 run a minute\n")
+		assert.Contains(t, res, "\nbusiest 09:00, 1 run from 1\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// ANSI colours
+	t.Run("Colour", func(t *testing.T) {
+		res, err := Heatmap(parse("0 9 * * * /a", "0-3 9 * * * /b"), HeatmapOptions{Bucket: 60, Colour: true})
+		assert.Nil(t, err)
+		assert.Contains(t, res, "\n09 │\x1b[1;31m█\x1b[0m\x1b[1;31m█\x1b[0m│ 5\n")
+		assert.Contains(t, res, "\n\x1b[32m░\x1b[0m 1  \x1b[33m▒\x1b[0m 2  \x1b[31m▓\x1b[0m 3  \x1b[1;31m█\x1b[0m 4-5 runs in 60 minutes\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No fixed run times
+	t.Run("No_Runs", func(t *testing.T) {
+		res, err := Heatmap(parse("@reboot /cmd"), HeatmapOptions{Bucket: 30})
+		assert.Nil(t, err)
+		assert.True(t, strings.HasSuffix(res, "\nno runs\n1  @reboot /cmd\n"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid bucket
+	t.Run("Invalid_Bucket", func(t *testing.T) {
+		for _, bucket := range []int{-1, 7, 120} {
+			_, err := Heatmap(nil, HeatmapOptions{Bucket: bucket})
+			assert.EqualError(t, err, fmt.Sprintf("heatmap - bucket must divide an hour - %d", bucket))
+		}
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Heatmap_Header(t *testing.T) {
+	assert.Equal(t, "    0         10        20        30        40        50", heatmapHeader(1, 1))
+	assert.Equal(t, "    0   10  20  30  40  50", heatmapHeader(5, 2))
+	assert.Equal(t, "    0", heatmapHeader(30, 2))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Heatmap_Scale(t *testing.T) {
+	assert.Equal(t, "░ 1-2  ▒ 3-4  ▓ 5-6  █ 7-9 runs a minute", heatmapScale(9, 1, false))
+	assert.Equal(t, "▒ 1  ▓ 2  █ 3 runs in 5 minutes", heatmapScale(3, 5, false))
+	assert.Equal(t, "█ 1 run a minute", heatmapScale(1, 1, false))
+}
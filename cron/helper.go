@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"archive/zip"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// zoneinfo is the time zone database from Go's lib/time, embedded so
+// CRON_TZ and TZ prefixes give the same results on every host. It is
+// updated with make tzdata
+//
+//go:embed zoneinfo.zip
+var zoneinfo []byte
+
+var (
+	zonesOnce sync.Once
+	zones     map[string]*zip.File
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// UniqueIntSlice removes duplicates from a int slice
+func UniqueIntSlice(in IntSlice) IntSlice {
+	keys := make(map[int]bool)
+	list := IntSlice{}
+
+	for _, entry := range in {
+		if _, value := keys[entry]; !value {
+			keys[entry] = true
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseLocation returns the time zone with the given IANA name (ex
+// Europe/London). Zones come from the tzdata embedded in the package,
+// so the result does not depend on the host, other than for Local
+func ParseLocation(name string) (*time.Location, error) {
+	switch name {
+	case "":
+		return nil, fmt.Errorf("time zone - invalid - %s", name)
+	case "UTC":
+		return time.UTC, nil
+	case "Local":
+		return time.Local, nil
+	}
+
+	zonesOnce.Do(func() {
+		zones = map[string]*zip.File{}
+		if r, err := zip.NewReader(bytes.NewReader(zoneinfo), int64(len(zoneinfo))); err == nil {
+			for _, f := range r.File {
+				zones[f.Name] = f
+			}
+		}
+	})
+
+	f, ok := zones[name]
+	if !ok {
+		return nil, fmt.Errorf("time zone - invalid - %s", name)
+	}
+
+	data, err := readZipFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("time zone - invalid - %s", name)
+	}
+
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("time zone - invalid - %s", name)
+	}
+
+	return loc, nil
+}
+
+// readZipFile returns the contents of a file in a zip
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
@@ -0,0 +1,33 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Helper_UniqueIntSlice(t *testing.T) {
+	res := UniqueIntSlice(IntSlice{1, 1, 2, 3, 3, 4, 5, 6})
+	expected := IntSlice{1, 2, 3, 4, 5, 6}
+
+	assert.ElementsMatch(t, res, expected)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Helper_ParseLocation(t *testing.T) {
+	loc, err := ParseLocation("Australia/Sydney")
+	assert.Nil(t, err)
+	assert.Equal(t, "Australia/Sydney", loc.String())
+
+	_, err = ParseLocation("Mars/Olympus")
+	assert.EqualError(t, err, "time zone - invalid - Mars/Olympus")
+
+	_, err = ParseLocation("")
+	assert.EqualError(t, err, "time zone - invalid - ")
+
+	loc, err = ParseLocation("UTC")
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, loc)
+}
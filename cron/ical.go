@@ -0,0 +1,317 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// icalDays are the iCalendar names of the days of the week, from
+// Sunday
+var icalDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// icalLineLength is the longest a content line can be, in bytes,
+// before it is folded onto the next line
+const icalLineLength = 75
+
+// Layouts of iCalendar times in UTC and in a named zone
+const (
+	icalUTCLayout   = "20060102T150405Z"
+	icalLocalLayout = "20060102T150405"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ICalOptions changes how an iCalendar export is written
+//
+// Stamp is the DTSTAMP of every event (default now). Expand writes an
+// event for every run, even when a schedule maps onto an RRULE
+type ICalOptions struct {
+	Stamp  time.Time
+	Expand bool
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// RRule returns the iCalendar recurrence rule the schedule runs on,
+// without its start or end, and false when it doesn't map onto one
+// (ex FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0)
+//
+// Day rules, years and days that only need to match one of the day
+// fields have no equivalent, so those schedules don't map
+func (c Cron) RRule() (string, bool) {
+	dom, dow := c.dayFields()
+	if c.Kind != KindTime || len(c.Year) > 0 || len(c.DayOfMonthRules) > 0 || len(c.DayOfWeekRules) > 0 || (dom && dow && c.Days.Either()) {
+		return "", false
+	}
+
+	parts := []string{"FREQ=DAILY"}
+	if len(c.Month) < 12 {
+		parts = append(parts, "BYMONTH="+icalList(c.Month, nil))
+	}
+	if dom {
+		parts = append(parts, "BYMONTHDAY="+icalList(c.DayOfMonth, nil))
+	}
+	if dow {
+		parts = append(parts, "BYDAY="+icalList(c.DayOfWeek, icalDays))
+	}
+
+	seconds, _ := c.seconds()
+	parts = append(parts,
+		"BYHOUR="+icalList(c.Hour, nil),
+		"BYMINUTE="+icalList(c.Minute, nil),
+		"BYSECOND="+icalList(seconds, nil),
+	)
+
+	return strings.Join(parts, ";"), true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ICalendar returns a VCALENDAR with the runs of the schedules between
+// from and to, both inclusive, with the command as the summary of each
+// event
+//
+// A schedule is one recurring event when it maps onto an RRULE that
+// gives exactly the same runs, which needs a UTC or named time zone
+// and no runs changed by daylight saving time. Otherwise it is an
+// event for every run. @reboot and @every have no fixed run times, so
+// they have no events
+func ICalendar(crons []*Cron, from, to time.Time, opts ICalOptions) string {
+	stamp := opts.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var (
+		zones  []string
+		events []string
+		seen   = map[string]bool{}
+	)
+
+	for _, c := range crons {
+		var runs []time.Time
+		changed := false
+		for _, o := range c.OccurrencesBetween(from, to) {
+			if o.Change != DSTNone {
+				changed = true
+			}
+			if o.Runs() {
+				runs = append(runs, o.Time)
+			}
+		}
+		if c.Kind != KindTime || len(runs) == 0 {
+			continue
+		}
+
+		rule, ok := c.RRule()
+		if ok && !opts.Expand && !changed && len(runs) > 1 {
+			start, zone, ok := icalStart(c.Location, runs[0], from, to)
+			if ok {
+				if zone != "" && !seen[c.Location.String()] {
+					seen[c.Location.String()] = true
+					zones = append(zones, zone)
+				}
+
+				rule += ";UNTIL=" + runs[len(runs)-1].UTC().Format(icalUTCLayout)
+				events = append(events, icalEvent(c, icalUID(c, time.Time{}), stamp, start, "RRULE:"+rule))
+				continue
+			}
+		}
+
+		for _, t := range runs {
+			events = append(events, icalEvent(c, icalUID(c, t), stamp, "DTSTART:"+t.UTC().Format(icalUTCLayout), ""))
+		}
+	}
+
+	var sb strings.Builder
+	writeICalLines(&sb, "BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//visualcron//visualcron//EN", "CALSCALE:GREGORIAN")
+	for _, zone := range zones {
+		sb.WriteString(zone)
+	}
+	for _, event := range events {
+		sb.WriteString(event)
+	}
+	writeICalLines(&sb, "END:VCALENDAR")
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// icalStart returns the DTSTART of a recurring event and the
+// VTIMEZONE it needs between from and to, if any. Local time has no
+// name a calendar app can look up, so it can't recur
+func icalStart(loc *time.Location, t, from, to time.Time) (start, zone string, ok bool) {
+	switch {
+	case loc == nil || loc == time.Local:
+		return "", "", false
+	case loc == time.UTC:
+		return "DTSTART:" + t.UTC().Format(icalUTCLayout), "", true
+	}
+
+	return fmt.Sprintf("DTSTART;TZID=%s:%s", loc, t.In(loc).Format(icalLocalLayout)), icalTimeZone(loc, from, to), true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// icalTimeZone returns a VTIMEZONE for loc, with the offset at from
+// and every change of offset up to to
+func icalTimeZone(loc *time.Location, from, to time.Time) string {
+	var sb strings.Builder
+	writeICalLines(&sb, "BEGIN:VTIMEZONE", "TZID:"+loc.String())
+
+	observance := func(t time.Time, before int) {
+		name, offset := t.In(loc).Zone()
+		kind := "STANDARD"
+		if t.In(loc).IsDST() {
+			kind = "DAYLIGHT"
+		}
+
+		// The onset is written in the wall clock time before it
+		onset := t.UTC().Add(time.Duration(before) * time.Second)
+		writeICalLines(&sb,
+			"BEGIN:"+kind,
+			"DTSTART:"+onset.Format(icalLocalLayout),
+			"TZOFFSETFROM:"+icalOffset(before),
+			"TZOFFSETTO:"+icalOffset(offset),
+			"TZNAME:"+name,
+			"END:"+kind,
+		)
+	}
+
+	_, offset := from.In(loc).Zone()
+	observance(from, offset)
+
+	// Offsets are checked twice a day, then the change is narrowed down
+	// to the second
+	for t := from; t.Before(to); {
+		next := t.Add(12 * time.Hour)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			t = next
+			continue
+		}
+
+		low, high := t, next
+		for high.Sub(low) > time.Second {
+			mid := low.Add(high.Sub(low) / 2)
+			if _, o := mid.In(loc).Zone(); o == offset {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+
+		observance(high.Truncate(time.Second), offset)
+		offset = nextOffset
+		t = next
+	}
+
+	writeICalLines(&sb, "END:VTIMEZONE")
+
+	return sb.String()
+}
+
+// icalOffset returns a UTC offset in seconds as +hhmm, or +hhmmss when
+// it isn't a whole number of minutes
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	if seconds%60 != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// icalEvent returns a VEVENT for the schedule, starting at start and
+// recurring on rule if it isn't empty
+func icalEvent(c *Cron, uid string, stamp time.Time, start, rule string) string {
+	summary := c.Command
+	if summary == "" {
+		summary = c.Original
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:" + stamp.UTC().Format(icalUTCLayout),
+		start,
+	}
+	if rule != "" {
+		lines = append(lines, rule)
+	}
+	lines = append(lines,
+		"SUMMARY:"+icalText(summary),
+		"DESCRIPTION:"+icalText(c.Original),
+		"END:VEVENT",
+	)
+
+	var sb strings.Builder
+	writeICalLines(&sb, lines...)
+	return sb.String()
+}
+
+// icalUID returns an ID that is the same every time the schedule is
+// exported, so calendar apps update events instead of adding them. A
+// zero t is the ID of a recurring event
+func icalUID(c *Cron, t time.Time) string {
+	h := fnv.New64a()
+	h.Write([]byte(c.Original))
+
+	if t.IsZero() {
+		return fmt.Sprintf("%x@visualcron", h.Sum64())
+	}
+	return fmt.Sprintf("%x-%d@visualcron", h.Sum64(), t.Unix())
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// icalList joins values with commas, using names for them if given
+func icalList(values IntSlice, names []string) string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		if names != nil {
+			result[i] = names[v%len(names)]
+		} else {
+			result[i] = fmt.Sprint(v)
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+// icalText escapes the characters with a meaning in iCalendar text
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeICalLines writes content lines ending in CRLF, folding any
+// longer than icalLineLength bytes without splitting a character
+func writeICalLines(sb *strings.Builder, lines ...string) {
+	for _, line := range lines {
+		limit := icalLineLength
+		for len(line) > limit {
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+
+			sb.WriteString(line[:cut] + "\r\n ")
+			line = line[cut:]
+
+			// Folded lines start with a space
+			limit = icalLineLength - 1
+		}
+		sb.WriteString(line + "\r\n")
+	}
+}
@@ -0,0 +1,256 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_ICal_RRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		expected string
+	}{
+		{"Daily", "0 9 * * * /cmd", "FREQ=DAILY;BYHOUR=9;BYMINUTE=0;BYSECOND=0"},
+		{"Weekdays", "0,30 9 * * 1-5 /cmd", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0,30;BYSECOND=0"},
+		{"Sunday", "0 9 * * 7 /cmd", "FREQ=DAILY;BYDAY=SU;BYHOUR=9;BYMINUTE=0;BYSECOND=0"},
+		{"Months", "0 0 1 JAN,JUL * /cmd", "FREQ=DAILY;BYMONTH=1,7;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"Seconds", "*/20 0 12 ? * MON", "FREQ=DAILY;BYDAY=MO;BYHOUR=12;BYMINUTE=0;BYSECOND=0,20,40"},
+		{"Macro", "@weekly /cmd", "FREQ=DAILY;BYDAY=SU;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"Step_Day", "0 0 */2 * * /cmd", "FREQ=DAILY;BYMONTHDAY=1,3,5,7,9,11,13,15,17,19,21,23,25,27,29,31;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"Step_Weekday", "0 0 * * */2 /cmd", "FREQ=DAILY;BYDAY=SU,TU,TH,SA;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"Step_Day_Weekday", "0 0 */2 * MON /cmd", "FREQ=DAILY;BYMONTHDAY=1,3,5,7,9,11,13,15,17,19,21,23,25,27,29,31;BYDAY=MO;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"Whole_Day_Either", "0 0 1-31 * MON /cmd", "FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			res, ok := c.RRule()
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, res)
+
+			// The rule runs at the same times as the schedule
+			from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+			to := time.Date(2026, time.March, 31, 23, 59, 59, 0, time.UTC)
+			c.Location = time.UTC
+			assert.Equal(t, c.Between(from, to), expandRRule(t, res, from, to))
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Schedules without an equivalent rule
+	for _, exp := range []string{"0 0 1 * MON /cmd", "0 0 12 L * ?", "0 0 12 ? * MON#2", "0 0 12 1 1 ? 2030", "@reboot /cmd", "@every 1h /cmd"} {
+		t.Run("No_Rule_"+exp, func(t *testing.T) {
+			c, err := Parse(exp)
+			assert.Nil(t, err)
+
+			_, ok := c.RRule()
+			assert.False(t, ok)
+		})
+	}
+}
+
+// expandRRule returns the times a FREQ=DAILY rule from RRule runs at
+// between from and to, both inclusive
+func expandRRule(t *testing.T, rule string, from, to time.Time) []time.Time {
+	by := map[string][]string{}
+	for _, part := range strings.Split(rule, ";") {
+		key, values, _ := strings.Cut(part, "=")
+		by[key] = strings.Split(values, ",")
+	}
+	assert.Equal(t, []string{"DAILY"}, by["FREQ"])
+
+	matches := func(key string, value string) bool {
+		if by[key] == nil {
+			return true
+		}
+		for _, v := range by[key] {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+	number := func(value string) int {
+		n, err := strconv.Atoi(value)
+		assert.Nil(t, err)
+		return n
+	}
+
+	var res []time.Time
+	for day := from.Truncate(24 * time.Hour); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !matches("BYMONTH", strconv.Itoa(int(day.Month()))) ||
+			!matches("BYMONTHDAY", strconv.Itoa(day.Day())) ||
+			!matches("BYDAY", icalDays[day.Weekday()]) {
+			continue
+		}
+
+		for _, h := range by["BYHOUR"] {
+			for _, m := range by["BYMINUTE"] {
+				for _, sec := range by["BYSECOND"] {
+					tm := time.Date(day.Year(), day.Month(), day.Day(), number(h), number(m), number(sec), 0, time.UTC)
+					if !tm.Before(from) && !tm.After(to) {
+						res = append(res, tm)
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_ICal_ICalendar(t *testing.T) {
+	stamp := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	autumn := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)
+	spring := time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	// Exports two weeks from from
+	export := func(from time.Time, opts ICalOptions, exps ...string) string {
+		var crons []*Cron
+		for _, exp := range exps {
+			c, err := Parse(exp)
+			assert.Nil(t, err)
+			crons = append(crons, c)
+		}
+
+		opts.Stamp = stamp
+		return strings.ReplaceAll(ICalendar(crons, from, from.AddDate(0, 0, 14), opts), "\r\n", "\n")
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A rule in UTC
+	t.Run("UTC", func(t *testing.T) {
+		assert.Equal(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//visualcron//visualcron//EN
+CALSCALE:GREGORIAN
+BEGIN:VEVENT
+UID:635424d9f7c66f30@visualcron
+DTSTAMP:20261001T120000Z
+DTSTART:20261026T090000Z
+RRULE:FREQ=DAILY;BYDAY=MO;BYHOUR=9;BYMINUTE=0;BYSECOND=0;UNTIL=20261102T090
+ 000Z
+SUMMARY:/usr/bin/report
+DESCRIPTION:CRON_TZ=UTC 0 9 * * MON /usr/bin/report
+END:VEVENT
+END:VCALENDAR
+`, export(autumn, ICalOptions{}, "CRON_TZ=UTC 0 9 * * MON /usr/bin/report"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// An event for every run
+	t.Run("Expand", func(t *testing.T) {
+		assert.Equal(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//visualcron//visualcron//EN
+CALSCALE:GREGORIAN
+BEGIN:VEVENT
+UID:635424d9f7c66f30-1793005200@visualcron
+DTSTAMP:20261001T120000Z
+DTSTART:20261026T090000Z
+SUMMARY:/usr/bin/report
+DESCRIPTION:CRON_TZ=UTC 0 9 * * MON /usr/bin/report
+END:VEVENT
+BEGIN:VEVENT
+UID:635424d9f7c66f30-1793610000@visualcron
+DTSTAMP:20261001T120000Z
+DTSTART:20261102T090000Z
+SUMMARY:/usr/bin/report
+DESCRIPTION:CRON_TZ=UTC 0 9 * * MON /usr/bin/report
+END:VEVENT
+END:VCALENDAR
+`, export(autumn, ICalOptions{Expand: true}, "CRON_TZ=UTC 0 9 * * MON /usr/bin/report"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A named zone has its offsets in a VTIMEZONE
+	t.Run("Time_Zone", func(t *testing.T) {
+		res := export(autumn, ICalOptions{}, "CRON_TZ=Europe/London 0 9 * * MON /usr/bin/report")
+		assert.Contains(t, res, `
+BEGIN:VTIMEZONE
+TZID:Europe/London
+BEGIN:DAYLIGHT
+DTSTART:20261020T010000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0100
+TZNAME:BST
+END:DAYLIGHT
+BEGIN:STANDARD
+DTSTART:20261025T020000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0000
+TZNAME:GMT
+END:STANDARD
+END:VTIMEZONE
+`)
+		assert.Contains(t, res, "\nDTSTART;TZID=Europe/London:20261026T090000\n")
+		assert.Contains(t, res, ";UNTIL=20261102T090\n 000Z\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A time repeated when the clocks go back runs once, at the first,
+	// as it does in a rule
+	t.Run("Repeated", func(t *testing.T) {
+		res := export(autumn, ICalOptions{}, "CRON_TZ=Europe/London 30 1 * * * /cmd")
+		assert.Contains(t, res, "\nRRULE:FREQ=DAILY;BYHOUR=1;BYMINUTE=30;BYSECOND=0;UNTIL=20261102T013000Z\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A time skipped when the clocks go forward runs at the change, which
+	// a rule can't do, so it's an event for every run as are local time
+	// and day rules
+	t.Run("No_Rule", func(t *testing.T) {
+		res := export(spring, ICalOptions{}, "CRON_TZ=Europe/London 30 1 * * * /cmd", "0 0 12 L * ?")
+		assert.NotContains(t, res, "RRULE")
+		assert.NotContains(t, res, "VTIMEZONE")
+		assert.Equal(t, 14, strings.Count(res, "SUMMARY:/cmd\n"))
+		assert.Contains(t, res, "\nDTSTART:20260329T010000Z\nSUMMARY:/cmd\n")
+		assert.Contains(t, res, "\nDTSTART:20260331T120000Z\nSUMMARY:0 0 12 L * ?\n")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No fixed run times
+	t.Run("Reboot", func(t *testing.T) {
+		assert.NotContains(t, export(autumn, ICalOptions{}, "@reboot /cmd", "@every 1h /cmd"), "VEVENT")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_ICal_Format(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Offsets
+	t.Run("Offset", func(t *testing.T) {
+		assert.Equal(t, "+0000", icalOffset(0))
+		assert.Equal(t, "+0530", icalOffset(5*3600+30*60))
+		assert.Equal(t, "-0800", icalOffset(-8*3600))
+		assert.Equal(t, "-001915", icalOffset(-(19*60 + 15)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Text
+	t.Run("Text", func(t *testing.T) {
+		assert.Equal(t, `a\,b\;c\\d\ne`, icalText("a,b;c\\d\ne"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Long lines are folded without splitting characters
+	t.Run("Fold", func(t *testing.T) {
+		var sb strings.Builder
+		writeICalLines(&sb, "SUMMARY:"+strings.Repeat("é", 40))
+
+		lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n ")
+		assert.Equal(t, []string{"SUMMARY:" + strings.Repeat("é", 33), strings.Repeat("é", 7)}, lines)
+	})
+}
@@ -0,0 +1,265 @@
+package cron
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Kubernetes CronJob format
+//
+//	apiVersion: batch/v1
+//	kind: CronJob
+//	metadata:
+//	  name: backup
+//	  namespace: ops
+//	spec:
+//	  schedule: "0 3 * * *"
+//	  timeZone: Europe/London
+//	  suspend: false
+//	  concurrencyPolicy: Forbid
+//	  jobTemplate: ...
+//
+// The schedule is standard cron without a command, or a macro other
+// than @reboot. The time zone is set with timeZone, as a CRON_TZ or TZ
+// prefix in the schedule is rejected. Without one the schedule runs in
+// the time zone of the controller
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Manifest represents the CronJobs found in a Kubernetes manifest
+type Manifest struct {
+	CronJobs []CronJob
+	// Errors holds an error for each CronJob that could not be parsed
+	Errors []error
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CronJob represents a single Kubernetes CronJob
+//
+// Line is the line of its schedule. An empty ConcurrencyPolicy is
+// Allow. The command of Cron is the first container's command and
+// args, or its image when it has neither
+type CronJob struct {
+	Line              int    `json:"line" yaml:"line"`
+	Namespace         string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name              string `json:"name" yaml:"name"`
+	Schedule          string `json:"schedule" yaml:"schedule"`
+	TimeZone          string `json:"time_zone,omitempty" yaml:"time_zone,omitempty"`
+	Suspend           bool   `json:"suspend" yaml:"suspend"`
+	ConcurrencyPolicy string `json:"concurrency_policy,omitempty" yaml:"concurrency_policy,omitempty"`
+	Cron              *Cron  `json:"cron" yaml:"cron"`
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ID returns the namespace and name of the CronJob (ex ops/backup),
+// or only the name when it has no namespace
+func (j CronJob) ID() string {
+	if j.Namespace == "" {
+		return j.Name
+	}
+	return j.Namespace + "/" + j.Name
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Header returns the line, ID and settings of the CronJob as comment
+// lines, to go above its table
+func (j CronJob) Header() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# line %d\n", j.Line)
+	fmt.Fprintf(&sb, "# cronjob %s\n", j.ID())
+	if j.Suspend {
+		sb.WriteString("# suspended\n")
+	}
+
+	policy := j.ConcurrencyPolicy
+	if policy == "" {
+		policy = "Allow"
+	}
+	fmt.Fprintf(&sb, "# concurrency %s\n", policy)
+
+	return sb.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseManifest parses every CronJob in a Kubernetes manifest
+//
+// The manifest can hold several documents, as Helm renders them, and
+// the items of a List are searched too. Other kinds are skipped. A
+// CronJob that fails to parse is added to Errors and does not stop
+// the rest of the manifest being parsed. The dialect is always
+// standard, as Kubernetes only accepts that
+func ParseManifest(r io.Reader, opts ParseOptions) (*Manifest, error) {
+	manifest := &Manifest{}
+
+	decoder := yaml.NewDecoder(r)
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("manifest - %s", err)
+		}
+
+		for _, node := range doc.Content {
+			manifest.add(node, opts)
+		}
+	}
+
+	return manifest, nil
+}
+
+// add adds the CronJob in a document, or in the items of a List
+func (m *Manifest) add(node *yaml.Node, opts ParseOptions) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	switch yamlString(yamlPath(node, "kind")) {
+	case "List":
+		if items := yamlPath(node, "items"); items != nil && items.Kind == yaml.SequenceNode {
+			for _, item := range items.Content {
+				m.add(item, opts)
+			}
+		}
+	case "CronJob":
+		job, err := parseCronJob(node, opts)
+		if err != nil {
+			m.Errors = append(m.Errors, err)
+		} else {
+			m.CronJobs = append(m.CronJobs, *job)
+		}
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseCronJob reads the schedule and settings of a CronJob document
+// and parses the schedule. Errors start with the line and ID
+func parseCronJob(node *yaml.Node, opts ParseOptions) (*CronJob, error) {
+	job := &CronJob{
+		Line:              node.Line,
+		Namespace:         yamlString(yamlPath(node, "metadata", "namespace")),
+		Name:              yamlString(yamlPath(node, "metadata", "name")),
+		TimeZone:          yamlString(yamlPath(node, "spec", "timeZone")),
+		ConcurrencyPolicy: yamlString(yamlPath(node, "spec", "concurrencyPolicy")),
+	}
+
+	fail := func(err error) (*CronJob, error) {
+		return nil, fmt.Errorf("line %d - cronjob %s - %w", job.Line, job.ID(), err)
+	}
+
+	if suspend := yamlPath(node, "spec", "suspend"); suspend != nil {
+		if err := suspend.Decode(&job.Suspend); err != nil {
+			job.Line = suspend.Line
+			return fail(fmt.Errorf("suspend - invalid - %s", suspend.Value))
+		}
+	}
+
+	schedule := yamlPath(node, "spec", "schedule")
+	if schedule == nil || schedule.Kind != yaml.ScalarNode {
+		return fail(fmt.Errorf("spec.schedule - missing"))
+	}
+	job.Line = schedule.Line
+	job.Schedule = schedule.Value
+
+	c, err := parseK8sSchedule(job.Schedule, k8sCommand(node, job.Name), opts)
+	if err != nil {
+		return fail(err)
+	}
+
+	if job.TimeZone != "" {
+		loc, err := ParseLocation(job.TimeZone)
+		if err != nil {
+			return fail(timeZoneError(job.TimeZone, 0, job.TimeZone))
+		}
+		c.Location = loc
+	}
+
+	job.Cron = c
+	return job, nil
+}
+
+// parseK8sSchedule parses a schedule, followed by the command of its
+// CronJob so it reads as a crontab line
+func parseK8sSchedule(schedule, command string, opts ParseOptions) (*Cron, error) {
+	if parts := strings.Fields(schedule); len(parts) > 0 && parts[0] == "@reboot" {
+		return nil, &ParseError{
+			Expression: schedule,
+			Field:      "kubernetes",
+			Column:     fieldOffsets(schedule)[0],
+			Token:      parts[0],
+			Hint:       "use @yearly, @monthly, @weekly, @daily, @hourly or 5 fields",
+			Err:        fmt.Errorf("@reboot is not supported"),
+		}
+	}
+
+	return parseBareSchedule(schedule, command, "kubernetes", "set spec.timeZone instead (ex timeZone: Europe/London)", opts)
+}
+
+// k8sCommand returns the first container's command and args on one
+// line, or its image when it has neither, or else name
+func k8sCommand(node *yaml.Node, name string) string {
+	containers := yamlPath(node, "spec", "jobTemplate", "spec", "template", "spec", "containers")
+	if containers == nil || containers.Kind != yaml.SequenceNode || len(containers.Content) == 0 {
+		return name
+	}
+	container := containers.Content[0]
+
+	var words []string
+	for _, key := range []string{"command", "args"} {
+		if list := yamlPath(container, key); list != nil && list.Kind == yaml.SequenceNode {
+			for _, item := range list.Content {
+				words = append(words, strings.Fields(item.Value)...)
+			}
+		}
+	}
+
+	if len(words) > 0 {
+		return strings.Join(words, " ")
+	}
+	if image := yamlString(yamlPath(container, "image")); image != "" {
+		return image
+	}
+	return name
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// yamlPath returns the node at the keys of nested mappings, or nil
+// when one of them is missing
+func yamlPath(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+				break
+			}
+		}
+		node = value
+	}
+	return node
+}
+
+// yamlString returns the value of a scalar node, or "" for any other
+// node
+func yamlString(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
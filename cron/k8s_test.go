@@ -0,0 +1,175 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+const testManifest = `---
+# Source: jobs/templates/backup.yaml
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+  namespace: ops
+spec:
+  schedule: "0 3 * * 1-5"
+  timeZone: Europe/London
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: backup
+            image: backup:1.0
+            command: ["/bin/sh", "-c"]
+            args:
+            - |
+              backup --all
+              prune
+---
+# Source: jobs/templates/config.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: batch/v1
+  kind: CronJob
+  metadata:
+    name: report
+  spec:
+    schedule: "@hourly"
+    suspend: true
+    jobTemplate:
+      spec:
+        template:
+          spec:
+            containers:
+            - image: report:1.2
+- apiVersion: batch/v1
+  kind: CronJob
+  metadata:
+    name: broken
+  spec:
+    schedule: "0 3 * *"
+`
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_K8s_ParseManifest(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// CronJobs in several documents and a List
+	t.Run("CronJobs", func(t *testing.T) {
+		res, err := ParseManifest(strings.NewReader(testManifest), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.CronJobs, 2)
+
+		backup := res.CronJobs[0]
+		assert.Equal(t, 9, backup.Line)
+		assert.Equal(t, "ops/backup", backup.ID())
+		assert.Equal(t, "0 3 * * 1-5", backup.Schedule)
+		assert.Equal(t, "Forbid", backup.ConcurrencyPolicy)
+		assert.False(t, backup.Suspend)
+		assert.Equal(t, IntSlice{1, 2, 3, 4, 5}, backup.Cron.DayOfWeek)
+		assert.Equal(t, "Europe/London", backup.Cron.Location.String())
+		assert.Equal(t, "/bin/sh -c backup --all prune", backup.Cron.Command)
+
+		report := res.CronJobs[1]
+		assert.Equal(t, 39, report.Line)
+		assert.Equal(t, "report", report.ID())
+		assert.True(t, report.Suspend)
+		assert.Empty(t, report.ConcurrencyPolicy)
+		assert.Nil(t, report.Cron.Location)
+		assert.Equal(t, "report:1.2", report.Cron.Command)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Errors
+	t.Run("Errors", func(t *testing.T) {
+		res, err := ParseManifest(strings.NewReader(testManifest), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Errors, 1)
+		assert.EqualError(t, res.Errors[0], "line 52 - cronjob broken - not enough parts in the cron expression")
+
+		var pe *ParseError
+		assert.True(t, errors.As(res.Errors[0], &pe))
+		assert.Equal(t, "0 3 * *", pe.Expression)
+		assert.Equal(t, bareHint, pe.Hint)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid YAML
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseManifest(strings.NewReader("kind: CronJob\nspec:\n  schedule: \"0 3 * * *\n"), ParseOptions{})
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "manifest - "))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Malformed YAML is an error, not a panic
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := ParseManifest(strings.NewReader("0: [:!00 \xef"), ParseOptions{})
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "manifest - "))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Empty
+	t.Run("Empty", func(t *testing.T) {
+		res, err := ParseManifest(strings.NewReader("---\n# Source: jobs/templates/empty.yaml\n---\n"), ParseOptions{})
+		assert.Nil(t, err)
+		assert.Empty(t, res.CronJobs)
+		assert.Empty(t, res.Errors)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_K8s_CronJob(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{"Missing", "suspend: false", "line 1 - cronjob nightly - spec.schedule - missing"},
+		{"Suspend", "schedule: \"0 3 * * *\"\n  suspend: maybe", "line 6 - cronjob nightly - suspend - invalid - maybe"},
+		{"Fields", "schedule: \"0 0 3 * * *\"", "line 5 - cronjob nightly - parsing error - kubernetes - too many fields"},
+		{"TZ", "schedule: \"CRON_TZ=UTC 0 3 * * *\"", "line 5 - cronjob nightly - parsing error - kubernetes - a time zone in the schedule is rejected"},
+		{"Reboot", "schedule: \"@reboot\"", "line 5 - cronjob nightly - parsing error - kubernetes - @reboot is not supported"},
+		{"TimeZone", "schedule: \"0 3 * * *\"\n  timeZone: Mars/Olympus", "line 5 - cronjob nightly - parsing error - time zone - invalid - Mars/Olympus"},
+		{"Minute", "schedule: \"61 3 * * *\"", "line 5 - cronjob nightly - parsing error - minute - invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := "kind: CronJob\nmetadata:\n  name: nightly\nspec:\n  " + tc.spec + "\n"
+
+			res, err := ParseManifest(strings.NewReader(content), ParseOptions{})
+			assert.Nil(t, err)
+			assert.Empty(t, res.CronJobs)
+			if assert.Len(t, res.Errors, 1) {
+				assert.EqualError(t, res.Errors[0], tc.expected)
+			}
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Header
+	t.Run("Header", func(t *testing.T) {
+		job := CronJob{Line: 9, Namespace: "ops", Name: "backup", Suspend: true}
+		assert.Equal(t, "# line 9\n# cronjob ops/backup\n# suspended\n# concurrency Allow\n", job.Header())
+
+		job = CronJob{Line: 4, Name: "report", ConcurrencyPolicy: "Replace"}
+		assert.Equal(t, "# line 4\n# cronjob report\n# concurrency Replace\n", job.Header())
+	})
+}
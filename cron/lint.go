@@ -0,0 +1,416 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Severity is how serious a lint finding is
+type Severity int
+
+const (
+	// SeverityInfo is something worth knowing that is often intended
+	SeverityInfo Severity = iota
+	// SeverityWarning is likely a mistake
+	SeverityWarning
+	// SeverityError is a schedule that does not do what it says
+	SeverityError
+)
+
+// severityNames maps each Severity to its name
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseSeverity returns the Severity with the given name
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return s, nil
+		}
+	}
+
+	return SeverityInfo, fmt.Errorf("severity - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Rule is a check run by Lint
+//
+// check returns a message for each problem it finds in a schedule
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	check       func(c Cron) []string
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// rules are the lint rules, in the order their findings are returned
+var rules = []Rule{
+	{"never-runs", SeverityError, "no date matches every field (ex 0 0 30 2 *)", lintNeverRuns},
+	{"unescaped-percent", SeverityError, "cron turns % in the command into a newline unless it is escaped as \\%", lintUnescapedPercent},
+	{"single-step", SeverityWarning, "a step only produces one value (ex 30-40/20)", lintSingleStep},
+	{"redundant-item", SeverityWarning, "a list item is covered by the rest of the field (ex 5 in 1-10,5)", lintRedundantItem},
+	{"day-or", SeverityWarning, "day of month and day of week are both restricted, so either one matching is enough", lintDayOr},
+	{"every-minute", SeverityInfo, "the schedule runs every minute (or second)", lintEveryMinute},
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Rules returns the lint rules
+func Rules() []Rule {
+	result := make([]Rule, len(rules))
+	copy(result, rules)
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Finding is a problem found by a lint rule
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// LintOptions changes how a schedule is linted
+//
+// Disable holds the IDs of rules that are not run
+type LintOptions struct {
+	Disable []string
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// CheckRules returns an error for the first ID that is not a rule
+func CheckRules(ids []string) error {
+	for _, id := range ids {
+		if !isRule(id) {
+			return fmt.Errorf("lint - unknown rule - %s", id)
+		}
+	}
+	return nil
+}
+
+// isRule checks if id is the ID of a rule
+func isRule(id string) bool {
+	for _, r := range rules {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Lint runs every rule that is not disabled over the schedule
+//
+// Only time schedules are checked, as @reboot and @every have no
+// fields to get wrong
+func (c Cron) Lint(opts LintOptions) []Finding {
+	var result []Finding
+	if c.Kind != KindTime {
+		return result
+	}
+
+	for _, r := range rules {
+		if contains(opts.Disable, r.ID) {
+			continue
+		}
+
+		for _, message := range r.check(c) {
+			result = append(result, Finding{Rule: r.ID, Severity: r.Severity, Message: message})
+		}
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// disableDirective starts a comment that disables rules for the job
+// below it (ex # visualcron:disable day-or,every-minute)
+const disableDirective = "visualcron:disable"
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Lint lints the job, also leaving out the rules disabled by its
+// comments. An ID in a comment that is not a rule is an
+// unknown-rule error, so a typo can't leave a rule running
+func (e Entry) Lint(opts LintOptions) []Finding {
+	var unknown []Finding
+
+	disable := append([]string{}, opts.Disable...)
+	for _, comment := range e.Comments {
+		if !strings.HasPrefix(comment, disableDirective) {
+			continue
+		}
+
+		ids := strings.FieldsFunc(strings.TrimPrefix(comment, disableDirective), func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		for _, id := range ids {
+			if !isRule(id) {
+				unknown = append(unknown, Finding{
+					Rule:     "unknown-rule",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("%s names unknown rule %s", disableDirective, id),
+				})
+			}
+		}
+		disable = append(disable, ids...)
+	}
+
+	return append(unknown, e.Cron.Lint(LintOptions{Disable: disable})...)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintNeverRuns finds schedules that no date satisfies, such as the
+// 30th of February
+func lintNeverRuns(c Cron) []string {
+	if !c.Next(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil
+	}
+
+	src := c.Source
+	var message string
+	switch {
+	case !c.Days.DayOfWeekRestricted:
+		message = fmt.Sprintf("never runs, day of month %s is not in month %s", src.DayOfMonth, src.Month)
+	case !c.Days.DayOfMonthRestricted:
+		message = fmt.Sprintf("never runs, no day in month %s matches day of week %s", src.Month, src.DayOfWeek)
+	default:
+		message = fmt.Sprintf("never runs, no day in month %s matches day of month %s and day of week %s", src.Month, src.DayOfMonth, src.DayOfWeek)
+	}
+	if len(c.Year) > 0 {
+		message += " in year " + src.Year
+	}
+
+	return []string{message}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintUnescapedPercent finds % in the command of a standard cron job,
+// which cron sends to the command as input instead
+func lintUnescapedPercent(c Cron) []string {
+	if c.Source.Dialect != DialectStandard {
+		return nil
+	}
+
+	for i, r := range c.Command {
+		if r == '%' && (i == 0 || c.Command[i-1] != '\\') {
+			return []string{"the command has an unescaped %, which cron turns into a newline, escape it as \\%"}
+		}
+	}
+
+	return nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintSingleStep finds steps that only produce one value, which are
+// usually a step bigger than the range it steps over (ex 30-40/20)
+func lintSingleStep(c Cron) []string {
+	var result []string
+
+	for _, f := range c.lintFields() {
+		for _, item := range f.items() {
+			if strings.Contains(item.text, "/") && len(item.values) == 1 {
+				result = append(result, fmt.Sprintf("step %s in %s only runs at %d", item.text, f.name, item.values[0]))
+			}
+		}
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintRedundantItem finds list items whose values are all covered by
+// the other items of the field. Items already reported are not used
+// to cover the rest, so only one of two identical items is reported
+func lintRedundantItem(c Cron) []string {
+	var result []string
+
+	for _, f := range c.lintFields() {
+		items := f.items()
+		redundant := make([]bool, len(items))
+
+		for i, item := range items {
+			covered := map[int]bool{}
+			var by []string
+			for j, other := range items {
+				if j == i || redundant[j] {
+					continue
+				}
+				for _, v := range other.values {
+					covered[v] = true
+				}
+				if overlaps(item.values, other.values) {
+					by = append(by, other.text)
+				}
+			}
+
+			if len(by) > 0 && coveredBy(item.values, covered) {
+				redundant[i] = true
+				result = append(result, fmt.Sprintf("%s in %s is already covered by %s", item.text, f.name, strings.Join(by, ",")))
+			}
+		}
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintDayOr finds schedules where day of month and day of week are
+// both restricted, so they run when either matches
+func lintDayOr(c Cron) []string {
+	if !c.Days.Either() {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("runs on days matching day of month %s or day of week %s, not only days matching both", c.Source.DayOfMonth, c.Source.DayOfWeek)}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintEveryMinute finds schedules that run every minute, or every
+// second, of the hours they run in
+func lintEveryMinute(c Cron) []string {
+	unit := "minute"
+	switch {
+	case len(c.Second) == len(defaultMinuteSlice):
+		unit = "second"
+	case len(c.Second) > 1 || len(c.Minute) < len(defaultMinuteSlice):
+		return nil
+	}
+
+	if len(c.Hour) < len(defaultHourSlice) {
+		return []string{fmt.Sprintf("runs every %s of hour %s", unit, c.Source.Hour)}
+	}
+	return []string{"runs every " + unit}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintField is a field as it was written, for the rules that look at
+// its list items
+type lintField struct {
+	name string
+	text string
+	// values are the values the field accepts
+	values IntSlice
+	// replacer turns names into numbers, if the field has them
+	replacer *strings.Replacer
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintItem is a list item of a field and the values it produces
+type lintItem struct {
+	text   string
+	values IntSlice
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// lintFields returns the fields of the schedule as they were written
+func (c Cron) lintFields() []lintField {
+	src := c.Source
+
+	dow := lintField{"day of week", src.DayOfWeek, defaultDowSliceWithSunday, defaultDowSliceReplacer}
+	if src.Dialect.quartzWeekdays() {
+		dow = lintField{"day of week", src.DayOfWeek, quartzDowSlice, quartzDowSliceReplacer}
+	}
+
+	return []lintField{
+		{"second", src.Second, defaultMinuteSlice, nil},
+		{"minute", src.Minute, defaultMinuteSlice, nil},
+		{"hour", src.Hour, defaultHourSlice, nil},
+		{"day of month", src.DayOfMonth, defaultDomSlice, nil},
+		{"month", src.Month, defaultMonthSlice, defaultMonthSliceReplacer},
+		dow,
+		{"year", src.Year, defaultYearSlice, nil},
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// items returns the list items of the field. Items that are not plain
+// values, ranges or steps (ex L or 5#3) are left out
+func (f lintField) items() []lintItem {
+	var result []lintItem
+	if f.text == "" {
+		return result
+	}
+
+	for _, text := range strings.Split(f.text, ",") {
+		item := strings.ToUpper(text)
+		if f.replacer != nil {
+			item = f.replacer.Replace(item)
+		}
+
+		values, err := parseSegment(item, f.values)
+		if err != nil {
+			continue
+		}
+		result = append(result, lintItem{text, values})
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// overlaps checks if a and b share a value
+func overlaps(a, b IntSlice) bool {
+	for _, v := range a {
+		if b.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// coveredBy checks if every value is in covered
+func coveredBy(values IntSlice, covered map[int]bool) bool {
+	for _, v := range values {
+		if !covered[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// contains checks if ids holds id
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Lint_ParseSeverity(t *testing.T) {
+	for _, name := range []string{"info", "Warning", "ERROR"} {
+		t.Run(name, func(t *testing.T) {
+			res, err := ParseSeverity(name)
+			assert.Nil(t, err)
+			assert.Equal(t, strings.ToLower(name), res.String())
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseSeverity("fatal")
+		assert.EqualError(t, err, "severity - unknown - fatal")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Ordered from least to most severe
+	assert.True(t, SeverityInfo < SeverityWarning && SeverityWarning < SeverityError)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Lint_Rules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		expected []string
+	}{
+		// Never runs
+		{"Never_Runs", "0 0 30 2 * /cmd", []string{"never-runs - never runs, day of month 30 is not in month 2"}},
+		{"Never_Runs_Months", "0 0 31 APR,jun * /cmd", []string{"never-runs - never runs, day of month 31 is not in month APR,jun"}},
+		{"Never_Runs_Day_Of_Week", "0 0 12 ? 2 MON#5 2023", []string{"never-runs - never runs, no day in month 2 matches day of week MON#5 in year 2023"}},
+		{"Never_Runs_Year", "0 0 12 29 2 ? 2023", []string{"never-runs - never runs, day of month 29 is not in month 2 in year 2023"}},
+		{"Leap_Day", "0 0 29 2 * /cmd", nil},
+		// Unescaped percent
+		{"Percent", "0 0 * * * date +%F", []string{"unescaped-percent - the command has an unescaped %, which cron turns into a newline, escape it as \\%"}},
+		{"Percent_Escaped", "0 0 * * * date +\\%F", nil},
+		{"Percent_Quartz", "0 0 12 ? * MON date +%F", nil},
+		// Single step
+		{"Single_Step", "30-40/20 0 * * * /cmd", []string{"single-step - step 30-40/20 in minute only runs at 30"}},
+		{"Single_Step_Names", "0 0 1 JAN-JUN/6 * /cmd", []string{"single-step - step JAN-JUN/6 in month only runs at 1"}},
+		{"Step", "*/20 0 * * * /cmd", nil},
+		// Redundant item
+		{"Redundant_Range", "0 1-10,5 * * * /cmd", []string{"redundant-item - 5 in hour is already covered by 1-10"}},
+		{"Redundant_Duplicate", "0 5,5 * * * /cmd", []string{"redundant-item - 5 in hour is already covered by 5"}},
+		{"Redundant_Names", "0 0 * * MON,1 /cmd", []string{"redundant-item - MON in day of week is already covered by 1"}},
+		{"Redundant_Several", "0 0 1-10,5-15,11-20 * * /cmd", []string{"redundant-item - 5-15 in day of month is already covered by 1-10,11-20"}},
+		{"Overlapping", "0 1-10,5-15 * * * /cmd", nil},
+		{"Rules", "0 0 L,LW * * /cmd", nil},
+		// Day or
+		{"Day_Or", "0 0 1 * MON /cmd", []string{"day-or - runs on days matching day of month 1 or day of week MON, not only days matching both"}},
+		{"Day_Or_Wildcard", "0 0 */2 * MON /cmd", nil},
+		// Every minute
+		{"Every_Minute", "* * * * * /cmd", []string{"every-minute - runs every minute"}},
+		{"Every_Minute_Hour", "* 9-17 * * * /cmd", []string{"every-minute - runs every minute of hour 9-17"}},
+		{"Every_Second", "* * * * * ?", []string{"every-minute - runs every second"}},
+		{"Seconds_Once", "0 * * * * ?", []string{"every-minute - runs every minute"}},
+		// Several
+		{"Several", "0 0 1 * 1,1 date +%F", []string{
+			"unescaped-percent - the command has an unescaped %, which cron turns into a newline, escape it as \\%",
+			"redundant-item - 1 in day of week is already covered by 1",
+			"day-or - runs on days matching day of month 1 or day of week 1,1, not only days matching both",
+		}},
+		{"None", "*/15 0 1,15 * * /usr/bin/find", nil},
+		{"Macro", "@daily /cmd", nil},
+		{"Reboot", "@reboot /cmd", nil},
+		{"Every", "@every 1s /cmd", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			var res []string
+			for _, f := range c.Lint(LintOptions{}) {
+				res = append(res, f.Rule+" - "+f.Message)
+			}
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Both day fields only combine with and
+	t.Run("Never_Runs_Day_Match_And", func(t *testing.T) {
+		c, err := ParseWithOptions("0 0 30 2 MON /cmd", ParseOptions{DayMatch: DayMatchAnd})
+		assert.Nil(t, err)
+		assert.Equal(t, []Finding{
+			{"never-runs", SeverityError, "never runs, no day in month 2 matches day of month 30 and day of week MON"},
+		}, c.Lint(LintOptions{}))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Findings carry the severity of their rule
+	t.Run("Severity", func(t *testing.T) {
+		c, _ := Parse("* * 30 2 * /cmd")
+		findings := c.Lint(LintOptions{})
+		assert.Equal(t, []Finding{
+			{"never-runs", SeverityError, "never runs, day of month 30 is not in month 2"},
+			{"every-minute", SeverityInfo, "runs every minute"},
+		}, findings)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Lint_Disable(t *testing.T) {
+	c, _ := Parse("* * 1 * 1 date +%F")
+
+	ids := func(findings []Finding) []string {
+		var result []string
+		for _, f := range findings {
+			result = append(result, f.Rule)
+		}
+		return result
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Options
+	t.Run("Options", func(t *testing.T) {
+		assert.Equal(t, []string{"unescaped-percent", "day-or", "every-minute"}, ids(c.Lint(LintOptions{})))
+		assert.Equal(t, []string{"unescaped-percent"}, ids(c.Lint(LintOptions{Disable: []string{"day-or", "every-minute"}})))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Comments above a crontab job
+	t.Run("Comments", func(t *testing.T) {
+		disable := []string{"every-minute"}
+		entry := Entry{Cron: c, Comments: []string{"report", "visualcron:disable day-or, unescaped-percent"}}
+		assert.Empty(t, entry.Lint(LintOptions{Disable: disable}))
+		assert.Equal(t, []string{"every-minute"}, disable)
+
+		entry.Comments = []string{"visualcron:disable day-or"}
+		assert.Equal(t, []string{"unescaped-percent", "every-minute"}, ids(entry.Lint(LintOptions{})))
+
+		// An unknown rule is an error, and the rules that are known are
+		// still disabled
+		entry.Comments = []string{"visualcron:disable bogus,day-or"}
+		res := entry.Lint(LintOptions{Disable: disable})
+		assert.Equal(t, []string{"unknown-rule", "unescaped-percent"}, ids(res))
+		assert.Equal(t, Finding{"unknown-rule", SeverityError, "visualcron:disable names unknown rule bogus"}, res[0])
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Rule IDs
+	t.Run("CheckRules", func(t *testing.T) {
+		assert.Nil(t, CheckRules(nil))
+		assert.Nil(t, CheckRules([]string{"day-or", "never-runs"}))
+		assert.EqualError(t, CheckRules([]string{"day-or", "day-and"}), "lint - unknown rule - day-and")
+		assert.Len(t, Rules(), len(rules))
+	})
+}
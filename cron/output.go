@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"encoding/json"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// cronOutput is the serialised form of a Cron
+//
+// The field names are part of the output format and should not be
+// renamed. Value lists are left out for @reboot and @every, and
+// day_match is only set when both day fields are restricted. Warnings
+// are only set when parsing leniently skipped a token
+type cronOutput struct {
+	Original        string   `json:"original" yaml:"original"`
+	Kind            string   `json:"kind" yaml:"kind"`
+	Interval        string   `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Second          []int    `json:"second,omitempty" yaml:"second,omitempty,flow"`
+	Minute          []int    `json:"minute,omitempty" yaml:"minute,omitempty,flow"`
+	Hour            []int    `json:"hour,omitempty" yaml:"hour,omitempty,flow"`
+	DayOfMonth      []int    `json:"day_of_month,omitempty" yaml:"day_of_month,omitempty,flow"`
+	DayOfMonthRules []string `json:"day_of_month_rules,omitempty" yaml:"day_of_month_rules,omitempty,flow"`
+	Month           []int    `json:"month,omitempty" yaml:"month,omitempty,flow"`
+	DayOfWeek       []int    `json:"day_of_week,omitempty" yaml:"day_of_week,omitempty,flow"`
+	DayOfWeekRules  []string `json:"day_of_week_rules,omitempty" yaml:"day_of_week_rules,omitempty,flow"`
+	DayMatch        string   `json:"day_match,omitempty" yaml:"day_match,omitempty"`
+	TimeZone        string   `json:"time_zone,omitempty" yaml:"time_zone,omitempty"`
+	Year            []int    `json:"year,omitempty" yaml:"year,omitempty,flow"`
+	User            string   `json:"user,omitempty" yaml:"user,omitempty"`
+	Command         string   `json:"command" yaml:"command"`
+	Warnings        []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// output returns the serialised form of the Cron
+func (c Cron) output() cronOutput {
+	out := cronOutput{
+		Original:        c.Original,
+		Kind:            c.Kind.String(),
+		Second:          c.Second,
+		Minute:          c.Minute,
+		Hour:            c.Hour,
+		DayOfMonth:      c.DayOfMonth,
+		DayOfMonthRules: ruleStrings(c.DayOfMonthRules),
+		Month:           c.Month,
+		DayOfWeek:       c.DayOfWeek,
+		DayOfWeekRules:  ruleStrings(c.DayOfWeekRules),
+		Year:            c.Year,
+		User:            c.User,
+		Command:         c.Command,
+	}
+
+	if c.Kind == KindInterval {
+		out.Interval = c.Interval.String()
+	}
+
+	if !c.Days.IsZero() {
+		out.DayMatch = c.Days.Match.String()
+	}
+
+	if c.Location != nil {
+		out.TimeZone = c.Location.String()
+	}
+
+	for _, w := range c.Warnings {
+		out.Warnings = append(out.Warnings, w.Error())
+	}
+
+	return out
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// MarshalJSON returns the Cron as JSON
+func (c Cron) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.output())
+}
+
+// MarshalYAML returns the value to marshal the Cron as in YAML
+func (c Cron) MarshalYAML() (interface{}, error) {
+	return c.output(), nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// crontabOutput is the serialised form of a Crontab
+type crontabOutput struct {
+	Entries []Entry  `json:"entries" yaml:"entries"`
+	Errors  []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// output returns the serialised form of the Crontab, with the errors
+// as strings
+func (c Crontab) output() crontabOutput {
+	out := crontabOutput{Entries: c.Entries}
+	if out.Entries == nil {
+		out.Entries = []Entry{}
+	}
+
+	for _, err := range c.Errors {
+		out.Errors = append(out.Errors, err.Error())
+	}
+
+	return out
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// MarshalJSON returns the Crontab as JSON
+func (c Crontab) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.output())
+}
+
+// MarshalYAML returns the value to marshal the Crontab as in YAML
+func (c Crontab) MarshalYAML() (interface{}, error) {
+	return c.output(), nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ruleStrings returns each rule as it is written (ex 5#3)
+func ruleStrings(rules DayRules) []string {
+	var result []string
+	for _, r := range rules {
+		result = append(result, r.String())
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Output_Crontab(t *testing.T) {
+	cron, _ := Parse("0 2 * * * /usr/bin/backup")
+
+	crontab := Crontab{
+		Entries: []Entry{{Line: 3, Comments: []string{"backup"}, Env: map[string]string{"MAILTO": "ops"}, Cron: cron}},
+		Errors:  []error{errors.New("line 4 - not enough parts in the cron expression")},
+	}
+
+	b, err := json.Marshal(crontab)
+	assert.Nil(t, err)
+
+	var out map[string]interface{}
+	assert.Nil(t, json.Unmarshal(b, &out))
+
+	entry := out["entries"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(3), entry["line"])
+	assert.Equal(t, []interface{}{"backup"}, entry["comments"])
+	assert.Equal(t, map[string]interface{}{"MAILTO": "ops"}, entry["env"])
+	assert.Equal(t, "/usr/bin/backup", entry["cron"].(map[string]interface{})["command"])
+	assert.Equal(t, []interface{}{"line 4 - not enough parts in the cron expression"}, out["errors"])
+
+	// No entries is an empty list rather than null
+	empty, err := json.Marshal(Crontab{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"entries":[]}`, string(empty))
+}
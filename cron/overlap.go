@@ -0,0 +1,245 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// durationDirective starts a comment that sets how long the job below
+// it runs for (ex # visualcron:duration 15m)
+const durationDirective = "visualcron:duration"
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Job is a schedule and how long each of its runs takes. A zero
+// Duration is a run that finishes within the minute it starts
+type Job struct {
+	Cron     *Cron
+	Duration time.Duration
+}
+
+// OverlapOptions changes what an overlap report looks for
+//
+// Window is the length of the windows runs are counted in (default an
+// hour). Top limits the busy spans and windows kept (default 5)
+type OverlapOptions struct {
+	Window time.Duration
+	Top    int
+}
+
+// Overlap is when several jobs run together between two times. Jobs
+// are numbered from 1, in the order they were given
+//
+// Only time schedules are counted, as @reboot and @every have no
+// fixed run times
+type Overlap struct {
+	From time.Time
+	To   time.Time
+	Runs int
+	// Collisions are the instants two or more jobs start at, in order
+	Collisions []Collision
+	// Peak is the most jobs running in any one minute
+	Peak int
+	// Busy are the spans of minutes with two or more jobs running, the
+	// most jobs first
+	Busy []Span
+	// Windows are the windows with the most runs, busiest first
+	Windows []Window
+}
+
+// Collision is an instant several jobs start at
+type Collision struct {
+	Time time.Time
+	Jobs []int
+}
+
+// Span is a run of minutes with the same jobs running, from Start up
+// to End
+type Span struct {
+	Start time.Time
+	End   time.Time
+	Jobs  []int
+}
+
+// Window is a window of time and the runs that start in it
+type Window struct {
+	Start time.Time
+	End   time.Time
+	Runs  int
+	Jobs  []int
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Overlaps works out when the jobs run together between from and to,
+// both inclusive. A run that started before from still counts towards
+// the jobs running at once while it lasts
+func Overlaps(jobs []Job, from, to time.Time, opts OverlapOptions) Overlap {
+	window := opts.Window
+	if window <= 0 {
+		window = time.Hour
+	}
+	top := opts.Top
+	if top <= 0 {
+		top = 5
+	}
+
+	result := Overlap{From: from, To: to}
+
+	minutes := int(to.Sub(from)/time.Minute) + 1
+	running := make([][]int, minutes)
+	starts := map[int64][]int{}
+	windows := map[int]*Window{}
+
+	for i, job := range jobs {
+		if job.Cron.Kind != KindTime {
+			continue
+		}
+
+		for _, o := range job.Cron.OccurrencesBetween(from.Add(-job.Duration), to) {
+			if !o.Runs() {
+				continue
+			}
+
+			// Every minute the run overlaps, within the horizon
+			first := minuteIndex(from, o.Time)
+			last := first
+			if job.Duration > 0 {
+				last = minuteIndex(from, o.Time.Add(job.Duration-time.Nanosecond))
+			}
+			if first < 0 {
+				first = 0
+			}
+			if last >= minutes {
+				last = minutes - 1
+			}
+			for m := first; m <= last; m++ {
+				running[m] = addJob(running[m], i+1)
+			}
+
+			if o.Time.Before(from) {
+				continue
+			}
+
+			result.Runs++
+			starts[o.Time.Unix()] = addJob(starts[o.Time.Unix()], i+1)
+
+			w := int(o.Time.Sub(from) / window)
+			if windows[w] == nil {
+				start := from.Add(time.Duration(w) * window)
+				windows[w] = &Window{Start: start, End: start.Add(window)}
+			}
+			windows[w].Runs++
+			windows[w].Jobs = addJob(windows[w].Jobs, i+1)
+		}
+	}
+
+	// Collisions
+	for unix, ids := range starts {
+		if len(ids) > 1 {
+			result.Collisions = append(result.Collisions, Collision{Time: time.Unix(unix, 0).In(from.Location()), Jobs: ids})
+		}
+	}
+	sort.Slice(result.Collisions, func(i, j int) bool {
+		return result.Collisions[i].Time.Before(result.Collisions[j].Time)
+	})
+
+	// Minutes with the same jobs running are merged into spans
+	for m, ids := range running {
+		if len(ids) > result.Peak {
+			result.Peak = len(ids)
+		}
+		if len(ids) < 2 {
+			continue
+		}
+
+		start := from.Add(time.Duration(m) * time.Minute)
+		if n := len(result.Busy); n > 0 && result.Busy[n-1].End.Equal(start) && sameJobs(result.Busy[n-1].Jobs, ids) {
+			result.Busy[n-1].End = start.Add(time.Minute)
+			continue
+		}
+		result.Busy = append(result.Busy, Span{Start: start, End: start.Add(time.Minute), Jobs: ids})
+	}
+	sort.SliceStable(result.Busy, func(i, j int) bool {
+		return len(result.Busy[i].Jobs) > len(result.Busy[j].Jobs)
+	})
+	if len(result.Busy) > top {
+		result.Busy = result.Busy[:top]
+	}
+
+	// Windows
+	for _, w := range windows {
+		result.Windows = append(result.Windows, *w)
+	}
+	sort.Slice(result.Windows, func(i, j int) bool {
+		if result.Windows[i].Runs != result.Windows[j].Runs {
+			return result.Windows[i].Runs > result.Windows[j].Runs
+		}
+		return result.Windows[i].Start.Before(result.Windows[j].Start)
+	})
+	if len(result.Windows) > top {
+		result.Windows = result.Windows[:top]
+	}
+
+	return result
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Duration returns how long the job runs for, set by a comment above
+// it, or fallback when there isn't one
+func (e Entry) Duration(fallback time.Duration) (time.Duration, error) {
+	result := fallback
+	for _, comment := range e.Comments {
+		if !strings.HasPrefix(comment, durationDirective) {
+			continue
+		}
+
+		value := strings.TrimSpace(strings.TrimPrefix(comment, durationDirective))
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("overlap - invalid duration - %s", value)
+		}
+		result = d
+	}
+
+	return result, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// minuteIndex returns the minute of the horizon starting at from that
+// t is in, which is negative before from
+func minuteIndex(from, t time.Time) int {
+	d := t.Sub(from)
+	if d < 0 {
+		return int((d - time.Minute + 1) / time.Minute)
+	}
+	return int(d / time.Minute)
+}
+
+// addJob adds a job to the jobs of a minute, window or instant. Jobs
+// are added in order, so it's only already there if it was last
+func addJob(ids []int, id int) []int {
+	if len(ids) > 0 && ids[len(ids)-1] == id {
+		return ids
+	}
+	return append(ids, id)
+}
+
+// sameJobs checks if two lists have the same jobs
+func sameJobs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Overlap_Overlaps(t *testing.T) {
+	from := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return from.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	jobs := func(durations map[int]time.Duration, exps ...string) []Job {
+		var result []Job
+		for i, exp := range exps {
+			c, err := Parse("CRON_TZ=UTC " + exp)
+			assert.Nil(t, err)
+			result = append(result, Job{Cron: c, Duration: durations[i+1]})
+		}
+		return result
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Runs that start together
+	t.Run("Collisions", func(t *testing.T) {
+		res := Overlaps(jobs(nil, "0 0 * * * /a", "*/15 * * * * /b", "0 0 * * 6 /c"), from, at(2, 0), OverlapOptions{})
+
+		assert.Equal(t, 11, res.Runs)
+		assert.Equal(t, []Collision{
+			{at(0, 0), []int{1, 2, 3}},
+		}, res.Collisions)
+		assert.Equal(t, 3, res.Peak)
+		assert.Equal(t, []Span{{at(0, 0), at(0, 1), []int{1, 2, 3}}}, res.Busy)
+		assert.Equal(t, []Window{
+			{at(0, 0), at(1, 0), 6, []int{1, 2, 3}},
+			{at(1, 0), at(2, 0), 4, []int{2}},
+			{at(2, 0), at(3, 0), 1, []int{2}},
+		}, res.Windows)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Runs that last long enough to overlap the next job
+	t.Run("Durations", func(t *testing.T) {
+		durations := map[int]time.Duration{1: 20 * time.Minute, 2: 5 * time.Minute}
+		res := Overlaps(jobs(durations, "0 0 * * * /a", "10,15 0 * * * /b", "30 0 * * * /c"), from, at(1, 0), OverlapOptions{})
+
+		assert.Empty(t, res.Collisions)
+		assert.Equal(t, 2, res.Peak)
+		assert.Equal(t, []Span{{at(0, 10), at(0, 20), []int{1, 2}}}, res.Busy)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A run from before the horizon is still running
+	t.Run("Before", func(t *testing.T) {
+		durations := map[int]time.Duration{1: 2 * time.Hour}
+		res := Overlaps(jobs(durations, "0 23 * * * /a", "30 0 * * * /b"), from, at(1, 0), OverlapOptions{})
+
+		assert.Equal(t, 1, res.Runs)
+		assert.Equal(t, 2, res.Peak)
+		assert.Equal(t, []Span{{at(0, 30), at(0, 31), []int{1, 2}}}, res.Busy)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Busy spans with the most jobs come first, then the busiest windows
+	t.Run("Top", func(t *testing.T) {
+		res := Overlaps(jobs(nil, "0 * * * * /a", "0 */2 * * * /b", "0 */4 * * * /c"), from, at(23, 59), OverlapOptions{Window: 4 * time.Hour, Top: 2})
+
+		assert.Len(t, res.Collisions, 12)
+		assert.Equal(t, []Span{
+			{at(0, 0), at(0, 1), []int{1, 2, 3}},
+			{at(4, 0), at(4, 1), []int{1, 2, 3}},
+		}, res.Busy)
+		assert.Equal(t, []Window{
+			{at(0, 0), at(4, 0), 7, []int{1, 2, 3}},
+			{at(4, 0), at(8, 0), 7, []int{1, 2, 3}},
+		}, res.Windows)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No fixed run times
+	t.Run("Reboot", func(t *testing.T) {
+		res := Overlaps(jobs(nil, "@reboot /a", "@every 1m /b"), from, at(1, 0), OverlapOptions{})
+		assert.Equal(t, Overlap{From: from, To: at(1, 0)}, res)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Overlap_Duration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comments []string
+		expected time.Duration
+		err      string
+	}{
+		{"Fallback", []string{"nightly backup"}, time.Minute, ""},
+		{"Comment", []string{"visualcron:duration 15m"}, 15 * time.Minute, ""},
+		{"Last", []string{"visualcron:duration 15m", "visualcron:duration 1h30m"}, 90 * time.Minute, ""},
+		{"Invalid", []string{"visualcron:duration forever"}, 0, "overlap - invalid duration - forever"},
+		{"Negative", []string{"visualcron:duration -5m"}, 0, "overlap - invalid duration - -5m"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Entry{Comments: tc.comments}.Duration(time.Minute)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Overlap_MinuteIndex(t *testing.T) {
+	from := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, minuteIndex(from, from))
+	assert.Equal(t, 0, minuteIndex(from, from.Add(59*time.Second)))
+	assert.Equal(t, 90, minuteIndex(from, from.Add(90*time.Minute)))
+	assert.Equal(t, -1, minuteIndex(from, from.Add(-time.Second)))
+	assert.Equal(t, -2, minuteIndex(from, from.Add(-61*time.Second)))
+}
@@ -0,0 +1,982 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Standard cron format
+//
+// ┌───────────── minute (0 - 59)
+// │ ┌───────────── hour (0 - 23)
+// │ │ ┌───────────── day of the month (1 - 31)
+// │ │ │ ┌───────────── month (1 - 12 or JAN - DEC)
+// │ │ │ │ ┌───────────── day of the week (0 - 6 or SUN - SAT, 7 is also Sunday)
+// │ │ │ │ │
+// │ │ │ │ │
+// │ │ │ │ │
+// * * * * * <command>
+//
+// Note: The following Special characters
+//       * , - \ are supported
+//
+//  * == always
+//  , == separate items (ex 0,1,2)
+//  - == range (ex 0-15)
+//  / == step by (ex */15)
+//
+// Month and day of week names are case insensitive
+//
+// The day fields also accept the following Quartz special characters
+//
+//  ? == no specific value, the same as * (day of month and day of week)
+//  L == last day of the month, or L-3 for 3 days before (day of month)
+//  W == weekday nearest a day (ex 15W), or LW for the last weekday (day of month)
+//  L == last of a day in the month (ex 5L), or Saturday on its own (day of week)
+//  # == nth of a day in the month (ex 5#3) (day of week)
+//
+// The seconds and Quartz dialects add a leading seconds field
+// (0 - 59) and Quartz allows a trailing year field (1970 - 2099).
+// See Dialect
+//
+// The following macros can be used in place of the 5 time fields
+//
+//  @yearly (@annually)  == 0 0 1 1 *
+//  @monthly             == 0 0 1 * *
+//  @weekly              == 0 0 * * 0
+//  @daily (@midnight)   == 0 0 * * *
+//  @hourly              == 0 * * * *
+//  @reboot              == once, when the cron daemon starts
+//  @every <duration>    == at a fixed interval (ex @every 1h30m)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Default slices for various parts of a cron expression
+var (
+	defaultMinuteSlice = []int{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+		10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+		20, 21, 22, 23, 24, 25, 26, 27, 28, 29,
+		30, 31, 32, 33, 34, 35, 36, 37, 38, 39,
+		40, 41, 42, 43, 44, 45, 46, 47, 48, 49,
+		50, 51, 52, 53, 54, 55, 56, 57, 58, 59,
+	}
+
+	defaultHourSlice = defaultMinuteSlice[:24]
+
+	defaultDomSlice = defaultMinuteSlice[1:32]
+
+	defaultMonthSlice         = defaultMinuteSlice[1:13]
+	defaultMonthSliceReplacer = strings.NewReplacer(
+		"JAN", "1", "FEB", "2", "MAR", "3", "APR", "4", "MAY", "5", "JUN", "6",
+		"JUL", "7", "AUG", "8", "SEP", "9", "OCT", "10", "NOV", "11", "DEC", "12")
+
+	defaultDowSlice = defaultMinuteSlice[0:7]
+	// Vixie cron also accepts 7 for Sunday, which is folded into 0
+	// after parsing
+	defaultDowSliceWithSunday = defaultMinuteSlice[0:8]
+	defaultDowSliceReplacer   = strings.NewReplacer(
+		"SUN", "0", "MON", "1", "TUE", "2", "WED", "3", "THU", "4", "FRI", "5", "SAT", "6")
+
+	// Quartz numbers the days of the week 1 - 7 from Sunday, which
+	// are shifted to 0 - 6 after parsing
+	quartzDowSlice         = defaultMinuteSlice[1:8]
+	quartzDowSliceReplacer = strings.NewReplacer(
+		"SUN", "1", "MON", "2", "TUE", "3", "WED", "4", "THU", "5", "FRI", "6", "SAT", "7")
+
+	// A Quartz year field is told apart from a command by only
+	// holding year characters
+	yearRegex = regexp.MustCompile(`^[\d*,/-]+$`)
+
+	// A time zone prefix (ex CRON_TZ=Europe/London or TZ=UTC)
+	tzRegex = regexp.MustCompile(`^(?:CRON_TZ|TZ)=(\S+)$`)
+
+	defaultYearSlice = func() IntSlice {
+		result := make(IntSlice, 2099-1970+1)
+		for i := range result {
+			result[i] = 1970 + i
+		}
+		return result
+	}()
+
+	// Macros that expand to the 5 time fields
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseOptions changes how an expression is parsed
+//
+// System is for system crontabs (/etc/crontab and /etc/cron.d),
+// which have a user field between the time fields and the command.
+// DayMatch is how the day of month and day of week fields combine
+// and DST is how daylight saving time changes are handled. Lenient
+// turns unrecognised tokens in a field (ex abc in 5,abc) into
+// warnings on the Cron instead of errors
+type ParseOptions struct {
+	Dialect  Dialect
+	System   bool
+	DayMatch DayMatch
+	DST      DSTPolicy
+	Lenient  bool
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Parse parses a cron expression and
+// builds a Cron stuct
+//
+// The dialect is detected from the expression (see DialectAuto)
+func Parse(exp string) (*Cron, error) {
+	return ParseWithOptions(exp, ParseOptions{})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseWithOptions parses a cron expression using the
+// given options and builds a Cron struct
+func ParseWithOptions(exp string, opts ParseOptions) (*Cron, error) {
+	if opts.Dialect == DialectSystemd {
+		return parseOnCalendar(exp, opts)
+	}
+
+	// Split and validate number of parts. Any amount of whitespace
+	// can separate the fields
+	parts := strings.Fields(exp)
+	offsets := fieldOffsets(exp)
+
+	// Time zone prefix
+	if len(parts) > 0 {
+		if match := tzRegex.FindStringSubmatch(parts[0]); match != nil {
+			return parseTimeZone(exp, match[1], opts)
+		}
+	}
+
+	if opts.Dialect == DialectAWS || opts.Dialect == DialectAuto && awsRegex.MatchString(exp) {
+		return parseAWS(exp, opts)
+	}
+
+	if len(parts) > 0 && strings.HasPrefix(parts[0], "@") {
+		return parseMacro(exp, parts, opts)
+	}
+
+	if len(parts) < 6 {
+		hint := "expected 5 time fields followed by a command"
+		if opts.Dialect == DialectSeconds || opts.Dialect == DialectQuartz {
+			hint = "expected 6 time fields"
+		}
+		return nil, missingError(exp, hint)
+	}
+
+	dialect := opts.Dialect
+	if dialect == DialectAuto {
+		dialect = detectDialect(parts)
+	}
+
+	// Number of fields before the command
+	fields := 5
+	source := Source{Dialect: dialect}
+
+	// A 6th part that reads as a day of week is likely a time field
+	seconds := false
+	if dialect == DialectStandard {
+		_, _, err := parseDayOfWeek(parts[5], false)
+		seconds = err == nil
+	}
+
+	// fieldAt describes the nth part for errors
+	fieldAt := func(name string, n int, normalise func(string) string) field {
+		if normalise == nil {
+			normalise = func(item string) string { return item }
+		}
+		return field{name: name, start: offsets[n], text: parts[n], normalise: normalise, quartz: dialect == DialectQuartz, seconds: seconds}
+	}
+
+	var errs, warnings ParseErrors
+
+	// fail records the error from parsing a field and returns false
+	// when parsing has to stop. Unrecognised tokens are collected, as
+	// warnings when lenient, so every one of them is reported. A field
+	// left empty without them is always an error
+	fail := func(f field, err error, empty bool) bool {
+		var ue unrecognisedError
+		if !errors.As(err, &ue) {
+			errs = append(errs, fieldError(exp, f, err))
+			return false
+		}
+
+		if opts.Lenient && !empty {
+			warnings = append(warnings, unrecognisedErrors(exp, f, ue.tokens)...)
+		} else {
+			errs = append(errs, unrecognisedErrors(exp, f, ue.tokens)...)
+		}
+		return true
+	}
+
+	// Second
+	var second IntSlice
+	if dialect == DialectSeconds || dialect == DialectQuartz {
+		var err error
+		second, err = parseSegment(parts[0], defaultMinuteSlice)
+		if err != nil && !fail(fieldAt("second", 0, nil), err, len(second) == 0) {
+			return nil, errs.err()
+		}
+		source.Second = parts[0]
+
+		// The remaining fields are in the standard positions
+		parts = parts[1:]
+		offsets = offsets[1:]
+		fields++
+	}
+
+	source.Minute = parts[0]
+	source.Hour = parts[1]
+	source.DayOfMonth = parts[2]
+	source.Month = parts[3]
+	source.DayOfWeek = parts[4]
+
+	// Quartz needs ? in exactly one of the day fields
+	if dialect == DialectQuartz && (parts[2] == "?") == (parts[4] == "?") {
+		errs = append(errs, &ParseError{
+			Expression: exp,
+			Field:      "quartz",
+			Column:     offsets[2],
+			Token:      parts[2],
+			Hint:       "use ? in one of day of month or day of week (ex 0 0 12 ? * MON)",
+			Err:        fmt.Errorf("one of day of month or day of week must be ?"),
+		})
+		return nil, errs.err()
+	}
+
+	// Minute
+	minute, err := parseSegment(parts[0], defaultMinuteSlice)
+	if err != nil && !fail(fieldAt("minute", 0, nil), err, len(minute) == 0) {
+		return nil, errs.err()
+	}
+
+	// Hour
+	hour, err := parseSegment(parts[1], defaultHourSlice)
+	if err != nil && !fail(fieldAt("hour", 1, nil), err, len(hour) == 0) {
+		return nil, errs.err()
+	}
+
+	// Day of Month
+	dayOfMonth, dayOfMonthRules, err := parseDaySegment(strings.ToUpper(parts[2]), defaultDomSlice, extractDomRules)
+	if err != nil && !fail(fieldAt("day of month", 2, strings.ToUpper), err, len(dayOfMonth) == 0 && len(dayOfMonthRules) == 0) {
+		return nil, errs.err()
+	}
+
+	// Month
+	monthReplaced := defaultMonthSliceReplacer.Replace(strings.ToUpper(parts[3]))
+	month, err := parseSegment(monthReplaced, defaultMonthSlice)
+	if err != nil && !fail(fieldAt("month", 3, func(item string) string {
+		return defaultMonthSliceReplacer.Replace(strings.ToUpper(item))
+	}), err, len(month) == 0) {
+		return nil, errs.err()
+	}
+
+	// Day of Week
+	dayOfWeek, dayOfWeekRules, err := parseDayOfWeek(parts[4], dialect == DialectQuartz)
+	if err != nil {
+		replacer := defaultDowSliceReplacer
+		if dialect == DialectQuartz {
+			replacer = quartzDowSliceReplacer
+		}
+		if !fail(fieldAt("day of week", 4, func(item string) string {
+			return replacer.Replace(strings.ToUpper(item))
+		}), err, len(dayOfWeek) == 0 && len(dayOfWeekRules) == 0) {
+			return nil, errs.err()
+		}
+	}
+
+	// Year (any year when not given)
+	var year IntSlice
+	if dialect == DialectQuartz && len(parts) > 5 && yearRegex.MatchString(parts[5]) {
+		if parts[5] != "*" {
+			year, err = parseSegment(parts[5], defaultYearSlice)
+			if err != nil && !fail(fieldAt("year", 5, nil), err, len(year) == 0) {
+				return nil, errs.err()
+			}
+		}
+		source.Year = parts[5]
+
+		fields++
+	}
+
+	// Every unrecognised token is reported together
+	if len(errs) > 0 {
+		return nil, errs.err()
+	}
+
+	// Skipped tokens are left out of the source, so it describes the
+	// schedule that runs
+	for _, w := range warnings {
+		source.skip(w.Field, w.Token)
+	}
+
+	// User
+	user, fields, err := parseUser(exp, fields, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cron{
+		Original:        exp,
+		Source:          source,
+		Second:          second,
+		Minute:          minute,
+		Hour:            hour,
+		DayOfMonth:      dayOfMonth,
+		DayOfMonthRules: dayOfMonthRules,
+		Month:           month,
+		DayOfWeek:       dayOfWeek,
+		DayOfWeekRules:  dayOfWeekRules,
+		Days: Days{
+			Match:                opts.DayMatch,
+			DayOfMonthRestricted: restrictsDays(source.DayOfMonth),
+			DayOfWeekRestricted:  restrictsDays(source.DayOfWeek),
+		},
+		DST:      opts.DST,
+		Year:     year,
+		User:     user,
+		Command:  fieldsAfter(exp, fields),
+		Warnings: warnings,
+	}, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseTimeZone parses an expression that starts with a time zone,
+// such as CRON_TZ=Europe/London 0 9 * * *
+func parseTimeZone(exp, name string, opts ParseOptions) (*Cron, error) {
+	offsets := fieldOffsets(exp)
+
+	loc, err := ParseLocation(name)
+	if err != nil {
+		return nil, timeZoneError(exp, offsets[0]+strings.Index(exp[offsets[0]:], "=")+1, name)
+	}
+
+	// Parse the rest of the expression, keeping the original
+	cron, err := ParseWithOptions(fieldsAfter(exp, 1), opts)
+	if err != nil {
+		var errs ParseErrors
+		var pe *ParseError
+		if errors.As(err, &errs) {
+			for _, pe := range errs {
+				rebaseError(pe, exp, offsets)
+			}
+		} else if errors.As(err, &pe) {
+			rebaseError(pe, exp, offsets)
+		}
+		return nil, err
+	}
+
+	for _, pe := range cron.Warnings {
+		rebaseError(pe, exp, offsets)
+	}
+
+	cron.Original = exp
+	cron.Location = loc
+
+	return cron, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// skip removes an item from the named field of the source
+func (s *Source) skip(name, item string) {
+	text, ok := map[string]*string{
+		"second":       &s.Second,
+		"minute":       &s.Minute,
+		"hour":         &s.Hour,
+		"day of month": &s.DayOfMonth,
+		"month":        &s.Month,
+		"day of week":  &s.DayOfWeek,
+		"year":         &s.Year,
+	}[name]
+	if !ok {
+		return
+	}
+
+	var kept []string
+	for _, i := range strings.Split(*text, ",") {
+		if i != item {
+			kept = append(kept, i)
+		}
+	}
+	*text = strings.Join(kept, ",")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// rebaseError points an error in the expression after a time zone
+// prefix at the whole expression, using its field offsets
+func rebaseError(pe *ParseError, exp string, offsets []int) {
+	pe.Expression = exp
+	if len(offsets) > 1 {
+		pe.Column += offsets[1]
+	} else {
+		pe.Column = len(strings.TrimRightFunc(exp, unicode.IsSpace))
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseMacro parses an expression that starts with a macro, such as
+// @daily or @every 1h
+func parseMacro(exp string, parts []string, opts ParseOptions) (*Cron, error) {
+	name := strings.ToLower(parts[0])
+
+	switch name {
+	case "@reboot":
+		if len(parts) < 2 {
+			return nil, missingError(exp, "expected a command after @reboot")
+		}
+
+		user, fields, err := parseUser(exp, 1, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Cron{
+			Original: exp,
+			Kind:     KindReboot,
+			User:     user,
+			Command:  fieldsAfter(exp, fields),
+		}, nil
+
+	case "@every":
+		if len(parts) < 3 {
+			return nil, missingError(exp, "expected a duration and a command after @every")
+		}
+
+		// Cron daemons work in whole seconds
+		interval, err := time.ParseDuration(parts[1])
+		if err != nil || interval < time.Second || interval%time.Second != 0 {
+			return nil, &ParseError{
+				Expression: exp,
+				Field:      "every",
+				Column:     fieldOffsets(exp)[1],
+				Token:      parts[1],
+				Hint:       "use a whole number of seconds, at least 1s (ex 90s or 1h30m)",
+				Err:        errInvalid,
+			}
+		}
+
+		user, fields, err := parseUser(exp, 2, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Cron{
+			Original: exp,
+			Kind:     KindInterval,
+			Interval: interval,
+			User:     user,
+			Command:  fieldsAfter(exp, fields),
+		}, nil
+	}
+
+	fields, ok := macros[name]
+	if !ok {
+		return nil, &ParseError{
+			Expression: exp,
+			Field:      "macro",
+			Column:     fieldOffsets(exp)[0],
+			Token:      parts[0],
+			Hint:       "use one of @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly, @reboot or @every",
+			Err:        fmt.Errorf("unknown"),
+		}
+	}
+
+	if len(parts) < 2 {
+		return nil, missingError(exp, "expected a command after "+parts[0])
+	}
+
+	// Parse the expanded expression, keeping the original. Only the
+	// user and command can fail, which are at the end of both
+	expanded := fields + " " + fieldsAfter(exp, 1)
+	expandedOpts := opts
+	expandedOpts.Dialect = DialectStandard
+	cron, err := ParseWithOptions(expanded, expandedOpts)
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Expression = exp
+			pe.Column = len(strings.TrimRightFunc(exp, unicode.IsSpace))
+		}
+		return nil, err
+	}
+	cron.Original = exp
+
+	return cron, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseUser reads the user field that follows the first n fields of
+// a system crontab expression and returns the number of fields before
+// the command
+//
+// Nothing is read when the expression is not from a system crontab
+func parseUser(exp string, n int, opts ParseOptions) (string, int, error) {
+	if !opts.System {
+		return "", n, nil
+	}
+
+	// Both a user and a command are needed
+	parts := strings.Fields(exp)
+	if len(parts) < n+2 {
+		return "", n, missingError(exp, "expected a user followed by a command")
+	}
+
+	return parts[n], n + 1, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// fieldsAfter returns what follows the first n whitespace separated
+// fields of an expression, keeping its spacing (ex the command)
+func fieldsAfter(exp string, n int) string {
+	for i := 0; i < n; i++ {
+		exp = strings.TrimLeftFunc(exp, unicode.IsSpace)
+
+		end := strings.IndexFunc(exp, unicode.IsSpace)
+		if end < 0 {
+			return ""
+		}
+		exp = exp[end:]
+	}
+
+	return strings.TrimSpace(exp)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Hint for a schedule without a command
+const bareHint = "expected 5 fields: minute hour day-of-month month day-of-week (ex 0 3 * * *)"
+
+// parseBareSchedule parses a schedule from a config file, which has 5
+// fields or a macro and no command, followed by command so it reads
+// as a crontab line. The time zone is set elsewhere in the file, so a
+// prefix is an error with tzHint saying where. format names the file
+// format in errors
+func parseBareSchedule(schedule, command, format, tzHint string, opts ParseOptions) (*Cron, error) {
+	parts := strings.Fields(schedule)
+	offsets := fieldOffsets(schedule)
+
+	switch {
+	case len(parts) > 0 && tzRegex.MatchString(parts[0]):
+		return nil, &ParseError{
+			Expression: schedule,
+			Field:      format,
+			Column:     offsets[0],
+			Token:      parts[0],
+			Hint:       tzHint,
+			Err:        fmt.Errorf("a time zone in the schedule is rejected"),
+		}
+	case len(parts) > 0 && strings.HasPrefix(parts[0], "@"):
+	case len(parts) < 5:
+		return nil, missingError(schedule, bareHint)
+	case len(parts) > 5:
+		return nil, &ParseError{
+			Expression: schedule,
+			Field:      format,
+			Column:     offsets[5],
+			Token:      parts[5],
+			Hint:       bareHint,
+			Err:        fmt.Errorf("too many fields"),
+		}
+	}
+
+	opts.Dialect = DialectStandard
+	opts.System = false
+	return ParseWithOptions(schedule+" "+command, opts)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseSegment parses an individual segment of an expression,
+// such as the minute or hour
+//
+// Items that are not a number, range or step are returned in an
+// unrecognisedError, along with the values of the other items
+func parseSegment(expr string, inputSlice IntSlice) (IntSlice, error) {
+	var (
+		result       IntSlice
+		unrecognised []string
+	)
+
+	// Is empty
+	if expr == "" {
+		return result, errEmpty
+	}
+
+	// Wildcard
+	if expr == "*" {
+		result = make(IntSlice, len(inputSlice))
+		copy(result, inputSlice)
+		return result, nil
+	}
+
+	// Regexes (extremely generic on purpose)
+	singleRegex := regexp.MustCompile(`^(\d+)$`)
+	stepRegex := regexp.MustCompile(`^((?:(?:\d+-\d+)|\*)\/\d+)$`)
+	rangeRegex := regexp.MustCompile(`^(\d+-\d+)$`)
+
+	// Loop over each separate item
+	for _, exp := range strings.Split(expr, ",") {
+		if exp == "" {
+			continue
+		}
+
+		// Single number
+		match := singleRegex.MatchString(exp)
+		if match {
+			num, err := strconv.Atoi(exp)
+
+			if err == nil && num >= inputSlice[0] && num <= inputSlice[len(inputSlice)-1] {
+				result = append(result, num)
+			} else {
+				return result, tokenError{exp, errInvalid}
+			}
+
+			continue
+		}
+
+		// Step
+		match = stepRegex.MatchString(exp)
+		if match {
+			if out, err := explodeStep(exp, inputSlice); err != nil {
+				return result, tokenError{exp, err}
+			} else {
+				result = append(result, out...)
+			}
+
+			continue
+		}
+
+		// Range
+		match = rangeRegex.MatchString(exp)
+		if match {
+			if out, err := explodeRange(exp, inputSlice); err != nil {
+				return result, tokenError{exp, err}
+			} else {
+				result = append(result, out...)
+			}
+
+			continue
+		}
+
+		unrecognised = append(unrecognised, exp)
+	}
+
+	// Unique and sort
+	result = UniqueIntSlice(result)
+	sort.Ints(result)
+
+	if len(unrecognised) > 0 {
+		return result, unrecognisedError{unrecognised}
+	}
+
+	// Only commas
+	if len(result) == 0 {
+		return result, errEmpty
+	}
+
+	return result, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseDaySegment parses a day of month or day of week segment, which
+// can mix plain items with Quartz rules (ex 1,15,L)
+//
+// The extract func splits the rules out of the segment, leaving the
+// plain items for parseSegment
+func parseDaySegment(expr string, inputSlice IntSlice, extract func(string) (string, DayRules, error)) (IntSlice, DayRules, error) {
+	// No specific value
+	if expr == "?" {
+		expr = "*"
+	}
+
+	rest, rules, err := extract(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only rules
+	if rest == "" && len(rules) > 0 {
+		return nil, rules, nil
+	}
+
+	result, err := parseSegment(rest, inputSlice)
+	if err != nil && !isUnrecognised(err) {
+		return nil, nil, err
+	}
+
+	return result, rules, err
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// extractDomRules splits the L, L-n, LW and nW items out of a day of
+// month segment
+func extractDomRules(expr string) (string, DayRules, error) {
+	var (
+		rest  []string
+		rules DayRules
+	)
+
+	lastRegex := regexp.MustCompile(`^L(?:-(\d+))?$`)
+	weekdayRegex := regexp.MustCompile(`^(\d+)W$`)
+
+	for _, exp := range strings.Split(expr, ",") {
+		// Last weekday
+		if exp == "LW" {
+			rules = append(rules, DayRule{Kind: LastWeekdayOfMonth})
+			continue
+		}
+
+		// Last day, with an optional offset
+		if match := lastRegex.FindStringSubmatch(exp); match != nil {
+			offset := 0
+			if match[1] != "" {
+				offset, _ = strconv.Atoi(match[1])
+			}
+
+			if offset > 30 {
+				return "", nil, tokenError{exp, errLastInvalid}
+			}
+
+			rules = append(rules, DayRule{Kind: LastDayOfMonth, N: offset})
+			continue
+		}
+
+		// Nearest weekday
+		if match := weekdayRegex.FindStringSubmatch(exp); match != nil {
+			day, _ := strconv.Atoi(match[1])
+
+			if day < 1 || day > 31 {
+				return "", nil, tokenError{exp, errWeekdayInvalid}
+			}
+
+			rules = append(rules, DayRule{Kind: NearestWeekday, Day: day})
+			continue
+		}
+
+		rest = append(rest, exp)
+	}
+
+	return strings.Join(rest, ","), rules, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// parseDayOfWeek parses a day of week segment into days 0 - 6 from
+// Sunday
+//
+// Quartz numbers the days 1 - 7 from Sunday, otherwise both 0 and 7
+// are Sunday
+func parseDayOfWeek(expr string, quartz bool) (IntSlice, DayRules, error) {
+	extract := func(exp string) (string, DayRules, error) {
+		return extractDowRules(exp, quartz)
+	}
+
+	if quartz {
+		replaced := quartzDowSliceReplacer.Replace(strings.ToUpper(expr))
+		result, rules, err := parseDaySegment(replaced, quartzDowSlice, extract)
+		if err != nil && !isUnrecognised(err) {
+			return nil, nil, err
+		}
+
+		for i := range result {
+			result[i]--
+		}
+
+		return result, rules, err
+	}
+
+	replaced := defaultDowSliceReplacer.Replace(strings.ToUpper(expr))
+	result, rules, err := parseDaySegment(replaced, defaultDowSliceWithSunday, extract)
+	if err != nil && !isUnrecognised(err) {
+		return nil, nil, err
+	}
+
+	return foldSunday(result), rules, err
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// extractDowRules splits the nL and n#n items out of a day of week
+// segment. Names must already be replaced with numbers
+//
+// L on its own means Saturday and is left as a plain item. The days
+// in the rules are shifted to 0 - 6 from Sunday
+func extractDowRules(expr string, quartz bool) (string, DayRules, error) {
+	var (
+		rest  []string
+		rules DayRules
+	)
+
+	// Number of the first day and of Saturday
+	first, saturday := 0, "6"
+	if quartz {
+		first, saturday = 1, "7"
+	}
+
+	lastRegex := regexp.MustCompile(`^(\d+)L$`)
+	nthRegex := regexp.MustCompile(`^(\d+)#(\d+)$`)
+
+	for _, exp := range strings.Split(expr, ",") {
+		// Saturday
+		if exp == "L" {
+			rest = append(rest, saturday)
+			continue
+		}
+
+		// Last of a day
+		if match := lastRegex.FindStringSubmatch(exp); match != nil {
+			day, _ := strconv.Atoi(match[1])
+
+			if day < first || day > 7 {
+				return "", nil, tokenError{exp, errLastInvalid}
+			}
+
+			rules = append(rules, DayRule{Kind: LastDayOfWeek, Day: (day - first) % 7})
+			continue
+		}
+
+		// Nth of a day
+		if match := nthRegex.FindStringSubmatch(exp); match != nil {
+			day, _ := strconv.Atoi(match[1])
+			nth, _ := strconv.Atoi(match[2])
+
+			if day < first || day > 7 || nth < 1 || nth > 5 {
+				return "", nil, tokenError{exp, errNthInvalid}
+			}
+
+			rules = append(rules, DayRule{Kind: NthDayOfWeek, Day: (day - first) % 7, N: nth})
+			continue
+		}
+
+		rest = append(rest, exp)
+	}
+
+	return strings.Join(rest, ","), rules, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// explodeStep parses a step expression (ex */15) and explodes it
+// into a slice
+func explodeStep(stepExp string, inputSlice IntSlice) (IntSlice, error) {
+	var (
+		result, workingSlice IntSlice
+		step                 int
+	)
+
+	// Is empty
+	if stepExp == "" {
+		return result, fmt.Errorf("step - empty")
+	}
+
+	// Validate step expression
+	match, err := regexp.MatchString(`^(\d+-\d+|\*)\/\d+$`, stepExp)
+	if err != nil || !match {
+		return result, errStepInvalid
+	}
+
+	if stepExp[0] == '*' {
+		workingSlice = inputSlice
+
+		// Get the step
+		fmt.Sscanf(stepExp, "*/%d", &step)
+	} else {
+		start, end := -1, -1
+
+		// Get the start, end, step
+		fmt.Sscanf(stepExp, "%d-%d/%d", &start, &end, &step)
+
+		var err error
+		workingSlice, err = explodeRange(fmt.Sprintf("%d-%d", start, end), inputSlice)
+		if err != nil {
+			return result, fmt.Errorf("step - %w", err)
+		}
+	}
+
+	// Step never moves on
+	if step == 0 {
+		return result, errStepZero
+	}
+
+	// Step is too big
+	if step > workingSlice[len(workingSlice)-1] {
+		return result, errStepTooBig
+	}
+
+	// Build result
+	for i := 0; i < len(workingSlice); i += step {
+		result = append(result, workingSlice[i])
+	}
+
+	return result, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// explodeRange parses a range expression (1-15) and explodes it
+// into a slice
+func explodeRange(rangeExp string, inputSlice IntSlice) (result IntSlice, err error) {
+	start, end := -1, -1
+
+	// Is empty
+	if rangeExp == "" {
+		return result, fmt.Errorf("range - empty")
+	}
+
+	// Get the start and end
+	fmt.Sscanf(rangeExp, "%d-%d", &start, &end)
+
+	// Falls outside range
+	if end < start || start < 0 || start < inputSlice[0] || end > inputSlice[len(inputSlice)-1] {
+		return result, errRangeInvalid
+	}
+
+	// Build the result
+	result = make(IntSlice, end-start+1)
+	for i := range result {
+		result[i] = start + i
+	}
+
+	return result, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// foldSunday replaces 7 with 0 in a sorted day of week slice, as
+// both mean Sunday
+func foldSunday(in IntSlice) IntSlice {
+	if len(in) == 0 || in[len(in)-1] != 7 {
+		return in
+	}
+
+	result := append(IntSlice{0}, in[:len(in)-1]...)
+	return UniqueIntSlice(result)
+}
@@ -0,0 +1,1014 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_DefaultValues(t *testing.T) {
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	testCases := []struct {
+		name          string
+		slice         IntSlice
+		expectedSlice IntSlice
+	}{
+		{"Minute", defaultMinuteSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+			20, 21, 22, 23, 24, 25, 26, 27, 28, 29,
+			30, 31, 32, 33, 34, 35, 36, 37, 38, 39,
+			40, 41, 42, 43, 44, 45, 46, 47, 48, 49,
+			50, 51, 52, 53, 54, 55, 56, 57, 58, 59,
+		}},
+		{"Hour", defaultHourSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+			20, 21, 22, 23,
+		}},
+		{"Day_of_Month", defaultDomSlice, IntSlice{
+			1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+			20, 21, 22, 23, 24, 25, 26, 27, 28, 29,
+			30, 31,
+		}},
+		{"Month", defaultMonthSlice, IntSlice{
+			1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12,
+		}},
+		{"Day_of_week", defaultDowSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6,
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.expectedSlice, tc.slice)
+
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid replacer test cases
+	monthReplacerTestCases := []struct {
+		name           string
+		input          string
+		expectedOutput string
+	}{
+		{"Month", "JAN,FEB,MAR", "1,2,3"},
+		{"Month", "JAN-DEC", "1-12"},
+	}
+
+	for _, tc := range monthReplacerTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			replaced := defaultMonthSliceReplacer.Replace(tc.input)
+
+			assert.Equal(t, tc.expectedOutput, replaced)
+		})
+	}
+
+	dowReplacerTestCases := []struct {
+		name           string
+		input          string
+		expectedOutput string
+	}{
+		{"Day_of_week", "SUN,MON,TUE,WED,THU,FRI,SAT", "0,1,2,3,4,5,6"},
+		{"Day_of_week", "MON-FRI", "1-5"},
+		{"Day_of_week", "MON-SAT/2", "1-6/2"},
+	}
+
+	for _, tc := range dowReplacerTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			replaced := defaultDowSliceReplacer.Replace(tc.input)
+
+			assert.Equal(t, tc.expectedOutput, replaced)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_Parse(t *testing.T) {
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		expectedError string
+	}{
+		// Minute
+		{"Empty", "", "not enough parts in the cron expression"},
+		{"Not_Enough_Part", "1 2 3 4", "not enough parts in the cron expression"},
+		{"Invalid_Minute", "100 2 3 4 5 /command", "parsing error - minute - invalid"},
+		{"Invalid_Hour", "1 100 3 4 5 /command", "parsing error - hour - invalid"},
+		{"Invalid_DoM", "1 2 100 4 5 /command", "parsing error - day of month - invalid"},
+		{"Invalid_Month", "1 2 3 100 5 /command", "parsing error - month - invalid"},
+		{"Invalid_DoW", "1 2 3 4 100 /command", "parsing error - day of week - invalid"},
+		{"Invalid_DoW_Eight", "1 2 3 4 8 /command", "parsing error - day of week - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name        string
+		inputString string
+		expected    *Cron
+	}{
+		{"1", "1 2 3 4 5 /command", &Cron{
+			Original:   "1 2 3 4 5 /command",
+			Source:     Source{Dialect: DialectStandard, Minute: "1", Hour: "2", DayOfMonth: "3", Month: "4", DayOfWeek: "5"},
+			Minute:     IntSlice{1},
+			Hour:       IntSlice{2},
+			DayOfMonth: IntSlice{3},
+			Month:      IntSlice{4},
+			DayOfWeek:  IntSlice{5},
+			Days:       Days{DayOfMonthRestricted: true, DayOfWeekRestricted: true},
+			Command:    "/command"}},
+		{"2", "*/15 0 1,15 * 1-5 /usr/bin/find", &Cron{
+			Original:   "*/15 0 1,15 * 1-5 /usr/bin/find",
+			Source:     Source{Dialect: DialectStandard, Minute: "*/15", Hour: "0", DayOfMonth: "1,15", Month: "*", DayOfWeek: "1-5"},
+			Minute:     IntSlice{0, 15, 30, 45},
+			Hour:       IntSlice{0},
+			DayOfMonth: IntSlice{1, 15},
+			Month:      IntSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfMonthRestricted: true, DayOfWeekRestricted: true},
+			Command:    "/usr/bin/find"}},
+		{"3", "*/15 0 1,15 * 1-5 /usr/bin/find bob .", &Cron{
+			Original:   "*/15 0 1,15 * 1-5 /usr/bin/find bob .",
+			Source:     Source{Dialect: DialectStandard, Minute: "*/15", Hour: "0", DayOfMonth: "1,15", Month: "*", DayOfWeek: "1-5"},
+			Minute:     IntSlice{0, 15, 30, 45},
+			Hour:       IntSlice{0},
+			DayOfMonth: IntSlice{1, 15},
+			Month:      IntSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfMonthRestricted: true, DayOfWeekRestricted: true},
+			Command:    "/usr/bin/find bob ."}},
+		{"Whitespace", "*/15\t0  1,15 *\t1-5   /usr/bin/find  bob .", &Cron{
+			Original:   "*/15\t0  1,15 *\t1-5   /usr/bin/find  bob .",
+			Source:     Source{Dialect: DialectStandard, Minute: "*/15", Hour: "0", DayOfMonth: "1,15", Month: "*", DayOfWeek: "1-5"},
+			Minute:     IntSlice{0, 15, 30, 45},
+			Hour:       IntSlice{0},
+			DayOfMonth: IntSlice{1, 15},
+			Month:      IntSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfMonthRestricted: true, DayOfWeekRestricted: true},
+			Command:    "/usr/bin/find  bob ."}},
+		{"Day_Names", "0 9 * * MON-FRI /cmd", &Cron{
+			Original:   "0 9 * * MON-FRI /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "MON-FRI"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    "/cmd"}},
+		{"Names_Case_Insensitive", "0 9 * jan,Feb sun,Sat /cmd", &Cron{
+			Original:   "0 9 * jan,Feb sun,Sat /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "jan,Feb", DayOfWeek: "sun,Sat"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      IntSlice{1, 2},
+			DayOfWeek:  IntSlice{0, 6},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    "/cmd"}},
+		{"Sunday_Seven", "0 9 * * 5-7 /cmd", &Cron{
+			Original:   "0 9 * * 5-7 /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "5-7"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{0, 5, 6},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    "/cmd"}},
+		{"Sunday_Both", "0 9 * * 0,7 /cmd", &Cron{
+			Original:   "0 9 * * 0,7 /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "0,7"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{0},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    "/cmd"}},
+		{"Day_Wildcard", "0 9 * * * /cmd", &Cron{
+			Original:   "0 9 * * * /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "*"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  defaultDowSlice,
+			Command:    "/cmd"}},
+		{"Day_Step", "0 9 * * */2 /cmd", &Cron{
+			Original:   "0 9 * * */2 /cmd",
+			Source:     Source{Dialect: DialectStandard, Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "*/2"},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{0, 2, 4, 6},
+			Command:    "/cmd"}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.Nil(t, err)
+			assert.Equal(t, res, tc.expected)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseSegment(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedError string
+	}{
+		// Minute
+		{"Minute_Empty", "", defaultMinuteSlice, "empty"},
+		{"Minute_Invalid_Number", "70", defaultMinuteSlice, "invalid"},
+		{"Minute_Invalid_Range", "10-0", defaultMinuteSlice, "range - invalid"},
+		{"Minute_Invalid_Step", "10-0/2", defaultMinuteSlice, "step - range - invalid"},
+		{"Minute_Only_Commas", ",,", defaultMinuteSlice, "empty"},
+		{"Minute_Unrecognised", "abc,5-", defaultMinuteSlice, "unrecognised - abc,5-"},
+		// Hour
+		{"Hour_Empty", "", defaultHourSlice, "empty"},
+		{"Hour_Invalid_Number", "70", defaultHourSlice, "invalid"},
+		{"Hour_Invalid_Range", "10-0", defaultHourSlice, "range - invalid"},
+		{"Hour_Invalid_Step", "10-0/2", defaultHourSlice, "step - range - invalid"},
+		// Day of Month
+		{"DoM_Empty", "", defaultDomSlice, "empty"},
+		{"DoM_Invalid_Number", "0", defaultDomSlice, "invalid"},
+		{"DoM_Invalid_Range", "10-1", defaultDomSlice, "range - invalid"},
+		{"DoM_Invalid_Step", "10-1/2", defaultDomSlice, "step - range - invalid"},
+		// Month
+		{"Month_Empty", "", defaultMonthSlice, "empty"},
+		{"Month_Invalid_Number", "0", defaultMonthSlice, "invalid"},
+		{"Month_Invalid_Range", "10-1", defaultMonthSlice, "range - invalid"},
+		{"Month_Invalid_Step", "10-1/2", defaultMonthSlice, "step - range - invalid"},
+		// Day of Week
+		{"DoW_Empty", "", defaultDowSlice, "empty"},
+		{"DoW_Invalid_Number", "8", defaultDowSlice, "invalid"},
+		{"DoW_Invalid_Range", "10-1", defaultDowSlice, "range - invalid"},
+		{"DoW_Invalid_Step", "10-1/2", defaultDowSlice, "step - range - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := parseSegment(tc.inputString, tc.inputSlice)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Unrecognised items are skipped, keeping the rest
+	t.Run("Unrecognised", func(t *testing.T) {
+		res, err := parseSegment("5,abc,10-12,x", defaultMinuteSlice)
+		assert.EqualError(t, err, "unrecognised - abc,x")
+		assert.True(t, isUnrecognised(err))
+		assert.Equal(t, IntSlice{5, 10, 11, 12}, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedSlice IntSlice
+	}{
+		// Minute
+		{"Minute_Wildcard", "*", defaultMinuteSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+			20, 21, 22, 23, 24, 25, 26, 27, 28, 29,
+			30, 31, 32, 33, 34, 35, 36, 37, 38, 39,
+			40, 41, 42, 43, 44, 45, 46, 47, 48, 49,
+			50, 51, 52, 53, 54, 55, 56, 57, 58, 59,
+		}},
+		{"Minute_Ranged_Step", "10-20/5", defaultMinuteSlice, IntSlice{10, 15, 20}},
+		{"Minute_Range", "10-15", defaultMinuteSlice, IntSlice{10, 11, 12, 13, 14, 15}},
+		{"Minute_Multiple", "1,10-15,20-26/2,59", defaultMinuteSlice, IntSlice{1, 10, 11, 12, 13, 14, 15, 20, 22, 24, 26, 59}},
+		{"Minute_Trailing_Comma", "1,", defaultMinuteSlice, IntSlice{1}},
+		// Hour
+		{"Hour_Wildcard", "*", defaultHourSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13,
+			14, 15, 16, 17, 18, 19, 20, 21, 22, 23,
+		}},
+		{"Hour_Ranged_Step", "10-23/5", defaultHourSlice, IntSlice{10, 15, 20}},
+		{"Hour_Range", "10-15", defaultHourSlice, IntSlice{10, 11, 12, 13, 14, 15}},
+		{"Hour_Multiple", "1,10-15,20-22/2,23", defaultHourSlice, IntSlice{1, 10, 11, 12, 13, 14, 15, 20, 22, 23}},
+		{"Hour_Trailing_Comma", "0,", defaultHourSlice, IntSlice{0}},
+		// Day of Month
+		{"DoM_Wildcard", "*", defaultDomSlice, IntSlice{
+			1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13,
+			14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24,
+			25, 26, 27, 28, 29, 30, 31,
+		}},
+		{"DoM_Ranged_Step", "10-31/5", defaultDomSlice, IntSlice{10, 15, 20, 25, 30}},
+		{"DoM_Range", "10-15", defaultDomSlice, IntSlice{10, 11, 12, 13, 14, 15}},
+		{"DoM_Multiple", "1,10-15,20-22/2,30", defaultDomSlice, IntSlice{1, 10, 11, 12, 13, 14, 15, 20, 22, 30}},
+		{"DoM_Trailing_Comma", "31,", defaultDomSlice, IntSlice{31}},
+		// Month
+		{"Month_Wildcard", "*", defaultMonthSlice, IntSlice{
+			1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+		}},
+		{"Month_Ranged_Step", "5-12/3", defaultMonthSlice, IntSlice{5, 8, 11}},
+		{"Month_Range", "9-12", defaultMonthSlice, IntSlice{9, 10, 11, 12}},
+		{"Month_Multiple", "1,3-5,8-12/2", defaultMonthSlice, IntSlice{1, 3, 4, 5, 8, 10, 12}},
+		{"Month_Trailing_Comma", "12,", defaultMonthSlice, IntSlice{12}},
+		// Day of Week
+		{"DoW_Wildcard", "*", defaultDowSlice, IntSlice{
+			0, 1, 2, 3, 4, 5, 6,
+		}},
+		{"DoW_Ranged_Step", "0-6/2", defaultDowSlice, IntSlice{0, 2, 4, 6}},
+		{"DoW_Range", "3-5", defaultDowSlice, IntSlice{3, 4, 5}},
+		{"DoW_Multiple", "0,1-3,4-6/2", defaultDowSlice, IntSlice{0, 1, 2, 3, 4, 6}},
+		{"DoW_Trailing_Comma", "0,", defaultDowSlice, IntSlice{0}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := parseSegment(tc.inputString, tc.inputSlice)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, res, tc.expectedSlice)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ExplodeStep(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedError string
+	}{
+		{"Empty", "", defaultMinuteSlice, "step - empty"},
+		{"Invalid Data", "test", defaultMinuteSlice, "step - invalid"},
+		{"Step Too Big", "*/100", defaultMinuteSlice, "step - step is too big"},
+		{"Step Zero", "*/0", defaultMinuteSlice, "step - step is zero"},
+		{"Range Step Zero", "0-59/0", defaultMinuteSlice, "step - step is zero"},
+		{"Invalid Range", "10-5/2", defaultMinuteSlice, "step - range - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := explodeStep(tc.inputString, tc.inputSlice)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedSlice IntSlice
+	}{
+		{"Wildcard", "*/15", defaultMinuteSlice, IntSlice{0, 15, 30, 45}},
+		{"Range", "10-25/5", defaultMinuteSlice, IntSlice{10, 15, 20, 25}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := explodeStep(tc.inputString, tc.inputSlice)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, res, tc.expectedSlice)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ExplodeRange(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedError string
+	}{
+		{"Empty", "", defaultMinuteSlice, "range - empty"},
+		{"Invalid Data", "test", defaultMinuteSlice, "range - invalid"},
+		{"Invalid Range", "10-5", defaultMinuteSlice, "range - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := explodeRange(tc.inputString, tc.inputSlice)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name          string
+		inputString   string
+		inputSlice    IntSlice
+		expectedSlice IntSlice
+	}{
+		{"Range", "10-20", defaultMinuteSlice, IntSlice{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := explodeRange(tc.inputString, tc.inputSlice)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, res, tc.expectedSlice)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_FoldSunday(t *testing.T) {
+	testCases := []struct {
+		name          string
+		inputSlice    IntSlice
+		expectedSlice IntSlice
+	}{
+		{"Empty", IntSlice{}, IntSlice{}},
+		{"No_Seven", IntSlice{1, 2, 3}, IntSlice{1, 2, 3}},
+		{"Seven", IntSlice{5, 6, 7}, IntSlice{0, 5, 6}},
+		{"Zero_And_Seven", IntSlice{0, 1, 7}, IntSlice{0, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSlice, foldSunday(tc.inputSlice))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseMacro(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		expectedError string
+	}{
+		{"Unknown", "@fortnightly /cmd", "parsing error - macro - unknown"},
+		{"No_Command", "@daily", "not enough parts in the cron expression"},
+		{"Reboot_No_Command", "@reboot", "not enough parts in the cron expression"},
+		{"Every_No_Command", "@every 1h", "not enough parts in the cron expression"},
+		{"Every_Invalid", "@every often /cmd", "parsing error - every - invalid"},
+		{"Every_Too_Small", "@every 500ms /cmd", "parsing error - every - invalid"},
+		{"Every_Part_Second", "@every 1.5s /cmd", "parsing error - every - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Expanded macros
+	expandedTestCases := []struct {
+		name        string
+		inputString string
+		equivalent  string
+	}{
+		{"Yearly", "@yearly /cmd", "0 0 1 1 * /cmd"},
+		{"Annually", "@annually /cmd", "0 0 1 1 * /cmd"},
+		{"Monthly", "@monthly /cmd", "0 0 1 * * /cmd"},
+		{"Weekly", "@weekly /cmd", "0 0 * * 0 /cmd"},
+		{"Daily", "@daily /usr/bin/backup --all", "0 0 * * * /usr/bin/backup --all"},
+		{"Midnight", "@midnight /cmd", "0 0 * * * /cmd"},
+		{"Hourly", "@HOURLY /cmd", "0 * * * * /cmd"},
+	}
+
+	for _, tc := range expandedTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.Nil(t, err)
+
+			expected, _ := Parse(tc.equivalent)
+			expected.Original = tc.inputString
+
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Reboot
+	t.Run("Reboot", func(t *testing.T) {
+		res, err := Parse("@reboot /usr/bin/startup now")
+		assert.Nil(t, err)
+		assert.Equal(t, &Cron{Original: "@reboot /usr/bin/startup now", Kind: KindReboot, Command: "/usr/bin/startup now"}, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Every
+	t.Run("Every", func(t *testing.T) {
+		res, err := Parse("@every 1h30m /cmd")
+		assert.Nil(t, err)
+		assert.Equal(t, &Cron{Original: "@every 1h30m /cmd", Kind: KindInterval, Interval: 90 * time.Minute, Command: "/cmd"}, res)
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseDaySegment(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		expectedError string
+	}{
+		{"DoM_Last_Offset", "1 2 L-31 4 5 /command", "parsing error - day of month - L - invalid"},
+		{"DoM_Weekday", "1 2 32W 4 5 /command", "parsing error - day of month - W - invalid"},
+		{"DoW_Last", "1 2 3 4 8L /command", "parsing error - day of week - L - invalid"},
+		{"DoW_Nth", "1 2 3 4 5#6 /command", "parsing error - day of week - # - invalid"},
+		{"DoW_Nth_Day", "1 2 3 4 9#1 /command", "parsing error - day of week - # - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name        string
+		inputString string
+		domSlice    IntSlice
+		domRules    DayRules
+		dowSlice    IntSlice
+		dowRules    DayRules
+	}{
+		{"DoM_Last", "0 0 L * * /cmd", nil, DayRules{{Kind: LastDayOfMonth}}, defaultDowSlice, nil},
+		{"DoM_Last_Lowercase", "0 0 l-2 * * /cmd", nil, DayRules{{Kind: LastDayOfMonth, N: 2}}, defaultDowSlice, nil},
+		{"DoM_Last_Weekday", "0 0 LW * * /cmd", nil, DayRules{{Kind: LastWeekdayOfMonth}}, defaultDowSlice, nil},
+		{"DoM_Mixed", "0 0 1,15W,L * * /cmd", IntSlice{1}, DayRules{{Kind: NearestWeekday, Day: 15}, {Kind: LastDayOfMonth}}, defaultDowSlice, nil},
+		{"DoM_No_Specific", "0 0 ? * MON /cmd", defaultDomSlice, nil, IntSlice{1}, nil},
+		{"DoW_Last", "0 0 * * 5L /cmd", defaultDomSlice, nil, nil, DayRules{{Kind: LastDayOfWeek, Day: 5}}},
+		{"DoW_Last_Name", "0 0 * * friL /cmd", defaultDomSlice, nil, nil, DayRules{{Kind: LastDayOfWeek, Day: 5}}},
+		{"DoW_Last_Sunday", "0 0 * * 7L /cmd", defaultDomSlice, nil, nil, DayRules{{Kind: LastDayOfWeek, Day: 0}}},
+		{"DoW_Nth", "0 0 * * FRI#3 /cmd", defaultDomSlice, nil, nil, DayRules{{Kind: NthDayOfWeek, Day: 5, N: 3}}},
+		{"DoW_Saturday", "0 0 * * L /cmd", defaultDomSlice, nil, IntSlice{6}, nil},
+		{"DoW_No_Specific", "0 0 15 * ? /cmd", IntSlice{15}, nil, defaultDowSlice, nil},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.domSlice, res.DayOfMonth)
+			assert.Equal(t, tc.domRules, res.DayOfMonthRules)
+			assert.Equal(t, tc.dowSlice, res.DayOfWeek)
+			assert.Equal(t, tc.dowRules, res.DayOfWeekRules)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseDialects(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		dialect       Dialect
+		expectedError string
+	}{
+		{"Seconds_Not_Enough_Parts", "0 1 2 3 4", DialectSeconds, "not enough parts in the cron expression"},
+		{"Seconds_Invalid", "60 1 2 3 4 5", DialectSeconds, "parsing error - second - invalid"},
+		{"Quartz_No_Specific", "0 0 12 * * MON", DialectQuartz, "parsing error - quartz - one of day of month or day of week must be ?"},
+		{"Quartz_Both_No_Specific", "0 0 12 ? * ?", DialectQuartz, "parsing error - quartz - one of day of month or day of week must be ?"},
+		{"Quartz_Day_Of_Week", "0 0 12 ? * 0", DialectQuartz, "parsing error - day of week - invalid"},
+		{"Quartz_Year", "0 0 12 ? * 1 1969", DialectQuartz, "parsing error - year - invalid"},
+		{"Quartz_Nth", "0 0 12 ? * 0#1", DialectQuartz, "parsing error - day of week - # - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseWithOptions(tc.inputString, ParseOptions{Dialect: tc.dialect})
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name        string
+		inputString string
+		dialect     Dialect
+		expected    *Cron
+	}{
+		{"Seconds", "*/20 0 9 * * MON-FRI", DialectSeconds, &Cron{
+			Original:   "*/20 0 9 * * MON-FRI",
+			Source:     Source{Dialect: DialectSeconds, Second: "*/20", Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "MON-FRI"},
+			Second:     IntSlice{0, 20, 40},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    ""}},
+		{"Seconds_Command", "30 0 9 * * 7 /cmd -v", DialectSeconds, &Cron{
+			Original:   "30 0 9 * * 7 /cmd -v",
+			Source:     Source{Dialect: DialectSeconds, Second: "30", Minute: "0", Hour: "9", DayOfMonth: "*", Month: "*", DayOfWeek: "7"},
+			Second:     IntSlice{30},
+			Minute:     IntSlice{0},
+			Hour:       IntSlice{9},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{0},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    "/cmd -v"}},
+		{"Seconds_Detected", "0 */5 * * * *", DialectAuto, &Cron{
+			Original:   "0 */5 * * * *",
+			Source:     Source{Dialect: DialectSeconds, Second: "0", Minute: "*/5", Hour: "*", DayOfMonth: "*", Month: "*", DayOfWeek: "*"},
+			Second:     IntSlice{0},
+			Minute:     IntSlice{0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55},
+			Hour:       defaultHourSlice,
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  defaultDowSlice,
+			Command:    ""}},
+		{"Seconds_Detected_Weekdays", "*/10 * * * * 1-5", DialectAuto, &Cron{
+			Original:   "*/10 * * * * 1-5",
+			Source:     Source{Dialect: DialectSeconds, Second: "*/10", Minute: "*", Hour: "*", DayOfMonth: "*", Month: "*", DayOfWeek: "1-5"},
+			Second:     IntSlice{0, 10, 20, 30, 40, 50},
+			Minute:     defaultMinuteSlice,
+			Hour:       defaultHourSlice,
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    ""}},
+		{"Quartz", "0 15 10 ? * 2-6", DialectAuto, &Cron{
+			Original:   "0 15 10 ? * 2-6",
+			Source:     Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "?", Month: "*", DayOfWeek: "2-6"},
+			Second:     IntSlice{0},
+			Minute:     IntSlice{15},
+			Hour:       IntSlice{10},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{1, 2, 3, 4, 5},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    ""}},
+		{"Quartz_Names", "0 15 10 ? * SUN,SAT", DialectQuartz, &Cron{
+			Original:   "0 15 10 ? * SUN,SAT",
+			Source:     Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "?", Month: "*", DayOfWeek: "SUN,SAT"},
+			Second:     IntSlice{0},
+			Minute:     IntSlice{15},
+			Hour:       IntSlice{10},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{0, 6},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    ""}},
+		{"Quartz_Rules", "0 15 10 ? * 6#3", DialectQuartz, &Cron{
+			Original:       "0 15 10 ? * 6#3",
+			Source:         Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "?", Month: "*", DayOfWeek: "6#3"},
+			Second:         IntSlice{0},
+			Minute:         IntSlice{15},
+			Hour:           IntSlice{10},
+			DayOfMonth:     defaultDomSlice,
+			Month:          defaultMonthSlice,
+			DayOfWeekRules: DayRules{{Kind: NthDayOfWeek, Day: 5, N: 3}},
+			Days:           Days{DayOfWeekRestricted: true},
+			Command:        ""}},
+		{"Quartz_Saturday", "0 15 10 ? * L", DialectQuartz, &Cron{
+			Original:   "0 15 10 ? * L",
+			Source:     Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "?", Month: "*", DayOfWeek: "L"},
+			Second:     IntSlice{0},
+			Minute:     IntSlice{15},
+			Hour:       IntSlice{10},
+			DayOfMonth: defaultDomSlice,
+			Month:      defaultMonthSlice,
+			DayOfWeek:  IntSlice{6},
+			Days:       Days{DayOfWeekRestricted: true},
+			Command:    ""}},
+		{"Quartz_Year", "0 15 10 L * ? 2030-2032", DialectQuartz, &Cron{
+			Original:        "0 15 10 L * ? 2030-2032",
+			Source:          Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "L", Month: "*", DayOfWeek: "?", Year: "2030-2032"},
+			Second:          IntSlice{0},
+			Minute:          IntSlice{15},
+			Hour:            IntSlice{10},
+			DayOfMonthRules: DayRules{{Kind: LastDayOfMonth}},
+			Month:           defaultMonthSlice,
+			DayOfWeek:       defaultDowSlice,
+			Days:            Days{DayOfMonthRestricted: true},
+			Year:            IntSlice{2030, 2031, 2032},
+			Command:         ""}},
+		{"Quartz_Any_Year_Command", "0 15 10 L * ? * /cmd", DialectQuartz, &Cron{
+			Original:        "0 15 10 L * ? * /cmd",
+			Source:          Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "L", Month: "*", DayOfWeek: "?", Year: "*"},
+			Second:          IntSlice{0},
+			Minute:          IntSlice{15},
+			Hour:            IntSlice{10},
+			DayOfMonthRules: DayRules{{Kind: LastDayOfMonth}},
+			Month:           defaultMonthSlice,
+			DayOfWeek:       defaultDowSlice,
+			Days:            Days{DayOfMonthRestricted: true},
+			Command:         "/cmd"}},
+		{"Quartz_Command", "0 15 10 L * ? /cmd", DialectQuartz, &Cron{
+			Original:        "0 15 10 L * ? /cmd",
+			Source:          Source{Dialect: DialectQuartz, Second: "0", Minute: "15", Hour: "10", DayOfMonth: "L", Month: "*", DayOfWeek: "?"},
+			Second:          IntSlice{0},
+			Minute:          IntSlice{15},
+			Hour:            IntSlice{10},
+			DayOfMonthRules: DayRules{{Kind: LastDayOfMonth}},
+			Month:           defaultMonthSlice,
+			DayOfWeek:       defaultDowSlice,
+			Days:            Days{DayOfMonthRestricted: true},
+			Command:         "/cmd"}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseWithOptions(tc.inputString, ParseOptions{Dialect: tc.dialect})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_FieldsAfter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		n        int
+		expected string
+	}{
+		{"None", "a b c", 0, "a b c"},
+		{"One", "a b c", 1, "b c"},
+		{"All", "a b c", 3, ""},
+		{"Too_Many", "a b", 5, ""},
+		{"Spacing", " a\t b  c   d ", 2, "c   d"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fieldsAfter(tc.input, tc.n))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseSystem(t *testing.T) {
+	opts := ParseOptions{System: true}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		expectedError string
+	}{
+		{"No_Command", "0 2 * * * root", "not enough parts in the cron expression"},
+		{"Macro_No_Command", "@daily root", "not enough parts in the cron expression"},
+		{"Reboot_No_Command", "@reboot root", "not enough parts in the cron expression"},
+		{"Every_No_Command", "@every 1h root", "not enough parts in the cron expression"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseWithOptions(tc.inputString, opts)
+			assert.NotNil(t, err)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name            string
+		inputString     string
+		dialect         Dialect
+		expectedUser    string
+		expectedCommand string
+	}{
+		{"Standard", "17 * * * * root cd / && run-parts --report /etc/cron.hourly", DialectAuto, "root", "cd / && run-parts --report /etc/cron.hourly"},
+		{"Seconds", "0 17 * * * * www-data /usr/bin/php cron.php", DialectSeconds, "www-data", "/usr/bin/php cron.php"},
+		{"Quartz_Year", "0 17 * ? * * 2030 backup /usr/bin/backup", DialectAuto, "backup", "/usr/bin/backup"},
+		{"Macro", "@daily root /usr/bin/backup", DialectAuto, "root", "/usr/bin/backup"},
+		{"Reboot", "@reboot root /usr/bin/startup", DialectAuto, "root", "/usr/bin/startup"},
+		{"Every", "@every 5m nobody /usr/bin/ping", DialectAuto, "nobody", "/usr/bin/ping"},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseWithOptions(tc.inputString, ParseOptions{Dialect: tc.dialect, System: true})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedUser, res.User)
+			assert.Equal(t, tc.expectedCommand, res.Command)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseTimeZone(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	errorTestCases := []struct {
+		name          string
+		inputString   string
+		expectedError string
+	}{
+		{"Unknown_Zone", "CRON_TZ=Mars/Olympus 0 9 * * * /cmd", "parsing error - time zone - invalid - Mars/Olympus"},
+		{"No_Fields", "TZ=UTC", "not enough parts in the cron expression"},
+		{"Invalid_Field", "TZ=UTC 61 9 * * * /cmd", "parsing error - minute - invalid"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.Empty(t, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name             string
+		inputString      string
+		expectedLocation string
+		expectedCommand  string
+	}{
+		{"Cron_TZ", "CRON_TZ=Europe/London 0 9 * * * /usr/bin/backup", "Europe/London", "/usr/bin/backup"},
+		{"TZ", "TZ=America/New_York 0 9 * * * /usr/bin/backup", "America/New_York", "/usr/bin/backup"},
+		{"Macro", "CRON_TZ=Asia/Tokyo @daily /usr/bin/backup", "Asia/Tokyo", "/usr/bin/backup"},
+		{"Quartz", "TZ=UTC 0 0 9 ? * MON", "UTC", ""},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Parse(tc.inputString)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedLocation, res.Location.String())
+			assert.Equal(t, tc.expectedCommand, res.Command)
+			assert.Equal(t, tc.inputString, res.Original)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No prefix
+	res, err := Parse("0 9 * * * /usr/bin/backup")
+	assert.Nil(t, err)
+	assert.Nil(t, res.Location)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Cron_ParseUnrecognised(t *testing.T) {
+	type token struct {
+		field  string
+		column int
+		token  string
+	}
+
+	testCases := []struct {
+		name     string
+		exp      string
+		dialect  Dialect
+		expected []token
+	}{
+		{"Single", "5,abc * * * * /cmd", DialectAuto, []token{{"minute", 2, "abc"}}},
+		{"Every_Field", "5,abc x,3 1,?? 1,FOO mon,BAR /cmd", DialectAuto, []token{
+			{"minute", 2, "abc"},
+			{"hour", 6, "x"},
+			{"day of month", 12, "??"},
+			{"month", 17, "FOO"},
+			{"day of week", 25, "BAR"},
+		}},
+		{"Spacing", "5,a\t\t5,b * * * /cmd", DialectAuto, []token{{"minute", 2, "a"}, {"hour", 7, "b"}}},
+		{"Time_Zone", "TZ=UTC 5,abc * * * * /cmd", DialectAuto, []token{{"minute", 9, "abc"}}},
+		{"Second", "1,s 0 12 ? * MON", DialectQuartz, []token{{"second", 2, "s"}}},
+	}
+
+	for _, tc := range testCases {
+		// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+		// Every token is an error by default
+		t.Run(tc.name+"_Strict", func(t *testing.T) {
+			res, err := ParseWithOptions(tc.exp, ParseOptions{Dialect: tc.dialect})
+			assert.Nil(t, res)
+
+			var errs ParseErrors
+			if !errors.As(err, &errs) {
+				var pe *ParseError
+				assert.True(t, errors.As(err, &pe))
+				errs = ParseErrors{pe}
+			}
+
+			assert.Len(t, errs, len(tc.expected))
+			for i, pe := range errs {
+				assert.Equal(t, tc.exp, pe.Expression)
+				assert.Equal(t, tc.expected[i], token{pe.Field, pe.Column, pe.Token})
+				assert.True(t, errors.Is(pe, errUnrecognised))
+			}
+		})
+
+		// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+		// Or a warning when lenient
+		t.Run(tc.name+"_Lenient", func(t *testing.T) {
+			res, err := ParseWithOptions(tc.exp, ParseOptions{Dialect: tc.dialect, Lenient: true})
+			assert.Nil(t, err)
+
+			assert.Len(t, res.Warnings, len(tc.expected))
+			for i, pe := range res.Warnings {
+				assert.Equal(t, tc.exp, pe.Expression)
+				assert.Equal(t, tc.expected[i], token{pe.Field, pe.Column, pe.Token})
+			}
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Other errors are still reported alone
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseWithOptions("5,abc 25 * * * /cmd", ParseOptions{Lenient: true})
+		assert.EqualError(t, err, "parsing error - hour - invalid")
+
+		_, err = Parse("5,abc 25 * * * /cmd")
+		assert.EqualError(t, err, "parsing error - minute - unrecognised - abc; parsing error - hour - invalid")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A field with nothing left is an error even when lenient
+	t.Run("Empty_Field", func(t *testing.T) {
+		_, err := ParseWithOptions("abc * * * * /cmd", ParseOptions{Lenient: true})
+		assert.EqualError(t, err, "parsing error - minute - unrecognised - abc")
+
+		res, err := ParseWithOptions("0 0 L,abc * * /cmd", ParseOptions{Lenient: true})
+		assert.Nil(t, err)
+		assert.Len(t, res.Warnings, 1)
+		assert.Equal(t, "L", res.Source.DayOfMonth)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Skipped tokens are left out of the source
+	t.Run("Source", func(t *testing.T) {
+		res, err := ParseWithOptions("5,abc,10 x,3 * 1,foo * /cmd", ParseOptions{Lenient: true})
+		assert.Nil(t, err)
+		assert.Equal(t, "5,10", res.Source.Minute)
+		assert.Equal(t, "3", res.Source.Hour)
+		assert.Equal(t, "1", res.Source.Month)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No warnings without unrecognised tokens
+	res, err := ParseWithOptions("5 * * * * /cmd", ParseOptions{Lenient: true})
+	assert.Nil(t, err)
+	assert.Nil(t, res.Warnings)
+}
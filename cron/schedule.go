@@ -0,0 +1,342 @@
+package cron
+
+import (
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// maxSearchYears is how many years are searched before deciding a
+// schedule never fires (ex 0 0 30 2 *). A leap day restricted to a
+// day of week can take decades to come around again
+const maxSearchYears = 50
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Next returns the first time after t that the schedule fires, in
+// the schedule's Location, or the location of t when it has none
+//
+// The zero time is returned when the schedule never fires. Interval
+// schedules fire one interval after t and reboot schedules never fire.
+// Daylight saving time changes follow the schedule's DSTPolicy
+func (c Cron) Next(t time.Time) time.Time {
+	if times := c.NextN(t, 1); len(times) > 0 {
+		return times[0]
+	}
+	return time.Time{}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// NextN returns the next n times after t that the schedule fires
+//
+// Fewer than n times are returned when the schedule stops firing, and
+// none when n is not positive
+func (c Cron) NextN(t time.Time, n int) []time.Time {
+	return runTimes(c.NextOccurrences(t, n))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// NextOccurrences returns the next n runs after t, along with any
+// runs skipped by daylight saving time on the way
+func (c Cron) NextOccurrences(t time.Time, n int) []Occurrence {
+	if n <= 0 {
+		return nil
+	}
+
+	switch c.Kind {
+	case KindReboot:
+		return nil
+	case KindInterval:
+		var result []Occurrence
+		for len(result) < n {
+			t = t.Truncate(time.Second).Add(c.Interval)
+			result = append(result, Occurrence{Time: t})
+		}
+		return result
+	}
+
+	return c.occurrences(t, n, false, time.Time{})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// nextWall returns the first wall clock time after t that matches
+// the fields, where t is a wall clock time in UTC (see wallClock)
+//
+// The zero time is returned when nothing matches
+func (c Cron) nextWall(t time.Time) time.Time {
+	loc := t.Location()
+	seconds, step := c.seconds()
+
+	// Start at the following whole minute (or second)
+	t = t.Truncate(step).Add(step)
+	yearLimit := c.yearLimit(t.Year(), maxSearchYears)
+
+	// Each step moves to the start of the next candidate unit and
+	// then checks all fields again, as a step may roll over into a
+	// different day, month or year
+	for t.Year() <= yearLimit {
+		// Year
+		if len(c.Year) > 0 && !c.Year.Contains(t.Year()) {
+			t = time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		// Month
+		if !c.Month.Contains(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		// Day
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		// Hour
+		if !c.Hour.Contains(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		// Minute
+		if !c.Minute.Contains(t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+
+		// Second
+		if !seconds.Contains(t.Second()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second()+1, 0, loc)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Prev returns the last time before t that the schedule fired, in
+// the schedule's Location, or the location of t when it has none
+//
+// The zero time is returned when the schedule never fires. Interval
+// schedules fired one interval before t and reboot schedules never fire.
+// Daylight saving time changes follow the schedule's DSTPolicy
+func (c Cron) Prev(t time.Time) time.Time {
+	if times := c.PrevN(t, 1); len(times) > 0 {
+		return times[0]
+	}
+	return time.Time{}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// PrevN returns the last n times before t that the schedule fired,
+// most recent first
+//
+// Fewer than n times are returned when the schedule stops firing, and
+// none when n is not positive
+func (c Cron) PrevN(t time.Time, n int) []time.Time {
+	return runTimes(c.PrevOccurrences(t, n))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// PrevOccurrences returns the last n runs before t, most recent
+// first, along with any runs skipped by daylight saving time on the
+// way
+func (c Cron) PrevOccurrences(t time.Time, n int) []Occurrence {
+	if n <= 0 {
+		return nil
+	}
+
+	switch c.Kind {
+	case KindReboot:
+		return nil
+	case KindInterval:
+		var result []Occurrence
+		for len(result) < n {
+			t = t.Truncate(time.Second).Add(-c.Interval)
+			result = append(result, Occurrence{Time: t})
+		}
+		return result
+	}
+
+	return c.occurrences(t, n, true, time.Time{})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// prevWall returns the last wall clock time before t that matches
+// the fields, where t is a wall clock time in UTC (see wallClock)
+//
+// The zero time is returned when nothing matches
+func (c Cron) prevWall(t time.Time) time.Time {
+	loc := t.Location()
+	seconds, step := c.seconds()
+
+	// Start at the latest whole minute (or second) before t
+	p := t.Truncate(step)
+	if !p.Before(t) {
+		p = p.Add(-step)
+	}
+	yearLimit := c.yearLimit(p.Year(), -maxSearchYears)
+
+	// Each step moves to the last minute (or second) of the previous
+	// candidate unit and then checks all fields again
+	for p.Year() >= yearLimit {
+		// Year
+		if len(c.Year) > 0 && !c.Year.Contains(p.Year()) {
+			p = time.Date(p.Year(), 1, 1, 0, 0, 0, 0, loc).Add(-step)
+			continue
+		}
+
+		// Month
+		if !c.Month.Contains(int(p.Month())) {
+			p = time.Date(p.Year(), p.Month(), 1, 0, 0, 0, 0, loc).Add(-step)
+			continue
+		}
+
+		// Day
+		if !c.matchesDay(p) {
+			p = time.Date(p.Year(), p.Month(), p.Day(), 0, 0, 0, 0, loc).Add(-step)
+			continue
+		}
+
+		// Hour
+		if !c.Hour.Contains(p.Hour()) {
+			p = time.Date(p.Year(), p.Month(), p.Day(), p.Hour(), 0, 0, 0, loc).Add(-step)
+			continue
+		}
+
+		// Minute
+		if !c.Minute.Contains(p.Minute()) {
+			p = time.Date(p.Year(), p.Month(), p.Day(), p.Hour(), p.Minute(), 0, 0, loc).Add(-step)
+			continue
+		}
+
+		// Second
+		if !seconds.Contains(p.Second()) {
+			p = p.Add(-time.Second)
+			continue
+		}
+
+		return p
+	}
+
+	return time.Time{}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Between returns every time the schedule fires from start up to
+// and including end
+//
+// Interval schedules are measured from start
+func (c Cron) Between(start, end time.Time) []time.Time {
+	return runTimes(c.OccurrencesBetween(start, end))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// OccurrencesBetween returns every run from start up to and including
+// end, along with any runs skipped by daylight saving time
+func (c Cron) OccurrencesBetween(start, end time.Time) []Occurrence {
+	switch c.Kind {
+	case KindReboot:
+		return nil
+	case KindInterval:
+		var result []Occurrence
+		for t := start.Truncate(time.Second).Add(c.Interval); !t.After(end); t = t.Add(c.Interval) {
+			result = append(result, Occurrence{Time: t})
+		}
+		return result
+	}
+
+	// Step back so a run at exactly start is included
+	return c.occurrences(start.Add(-time.Nanosecond), unlimitedRuns, false, end)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Matches checks if the schedule fires at t, which is the same as
+// Next returning t from just before it
+//
+// Only time schedules match, as @reboot and @every have no fixed
+// run times
+func (c Cron) Matches(t time.Time) bool {
+	if c.Kind != KindTime {
+		return false
+	}
+
+	for _, o := range c.occurrences(t.Add(-time.Nanosecond), 1, false, t) {
+		if o.Runs() && o.Time.Equal(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// seconds returns the seconds the schedule fires on and the smallest
+// step between two run times
+//
+// Schedules without a seconds field fire at second 0 and are stepped
+// a minute at a time
+func (c Cron) seconds() (IntSlice, time.Duration) {
+	if len(c.Second) == 0 {
+		return IntSlice{0}, time.Minute
+	}
+	return c.Second, time.Second
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// yearLimit returns the year to stop searching at, moving years from
+// the given year. A year field ends the search at its last (or first)
+// year instead
+func (c Cron) yearLimit(year, years int) int {
+	if len(c.Year) == 0 {
+		return year + years
+	}
+
+	if years < 0 {
+		return c.Year[0]
+	}
+	return c.Year[len(c.Year)-1]
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// in returns t in the schedule's Location, when it has one
+func (c Cron) in(t time.Time) time.Time {
+	if c.Location == nil {
+		return t
+	}
+	return t.In(c.Location)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// matchesDay checks if the day of month and day of week of t are
+// part of the schedule
+//
+// Both fields must match unless Days says either is enough
+func (c Cron) matchesDay(t time.Time) bool {
+	dom := c.DayOfMonth.Contains(t.Day()) || c.DayOfMonthRules.Matches(t)
+	dow := c.DayOfWeek.Contains(int(t.Weekday())) || c.DayOfWeekRules.Matches(t)
+
+	if c.Days.Either() {
+		return dom || dow
+	}
+
+	return dom && dow
+}
@@ -0,0 +1,318 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// date is a shorthand for a UTC time.Time
+func date(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Next(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		from     time.Time
+		expected time.Time
+	}{
+		{"Every_Minute", "* * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 10, 31)},
+		{"Skips_Seconds", "* * * * * /cmd", time.Date(2022, 5, 10, 10, 30, 45, 0, time.UTC), date(2022, 5, 10, 10, 31)},
+		{"Step", "*/15 * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 10, 45)},
+		{"Next_Hour", "0 * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 11, 0)},
+		{"Next_Day", "0 9 * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 11, 9, 0)},
+		{"Next_Month", "0 0 1 * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 6, 1, 0, 0)},
+		{"Next_Year", "0 0 1 1 * /cmd", date(2022, 5, 10, 10, 30), date(2023, 1, 1, 0, 0)},
+		{"Month_Length", "0 0 31 * * /cmd", date(2022, 4, 1, 0, 0), date(2022, 5, 31, 0, 0)},
+		{"Leap_Year", "0 0 29 2 * /cmd", date(2022, 1, 1, 0, 0), date(2024, 2, 29, 0, 0)},
+		{"Day_Of_Week", "30 8 * * 1-5 /cmd", date(2022, 5, 13, 9, 0), date(2022, 5, 16, 8, 30)},
+		{"Sunday", "0 0 * * 0 /cmd", date(2022, 5, 13, 9, 0), date(2022, 5, 15, 0, 0)},
+		{"Never", "0 0 30 2 * /cmd", date(2022, 1, 1, 0, 0), time.Time{}},
+		{"Last_Day", "0 0 L * * /cmd", date(2022, 2, 1, 0, 0), date(2022, 2, 28, 0, 0)},
+		{"Last_Weekday", "0 0 LW * * /cmd", date(2022, 7, 1, 0, 0), date(2022, 7, 29, 0, 0)},
+		{"Nearest_Weekday", "0 0 15W * * /cmd", date(2022, 5, 1, 0, 0), date(2022, 5, 16, 0, 0)},
+		{"Third_Friday", "0 0 ? * 5#3 /cmd", date(2022, 5, 1, 0, 0), date(2022, 5, 20, 0, 0)},
+		{"Last_Friday", "0 0 ? * 5L /cmd", date(2022, 5, 1, 0, 0), date(2022, 5, 27, 0, 0)},
+		{"Either_Day", "0 0 1 * MON /cmd", date(2022, 5, 10, 0, 0), date(2022, 5, 16, 0, 0)},
+		{"Either_Day_Of_Month", "0 0 1 * MON /cmd", date(2022, 5, 31, 0, 0), date(2022, 6, 1, 0, 0)},
+		{"Star_Step_Day", "0 0 */2 * MON /cmd", date(2022, 5, 10, 0, 0), date(2022, 5, 23, 0, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.Next(tc.from))
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Both days must match
+	t.Run("Day_Match_And", func(t *testing.T) {
+		c, err := ParseWithOptions("0 0 1 * MON /cmd", ParseOptions{DayMatch: DayMatchAnd})
+		assert.Nil(t, err)
+		assert.Equal(t, date(2022, 8, 1, 0, 0), c.Next(date(2022, 5, 10, 0, 0)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Location
+	t.Run("Location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+10", 10*60*60)
+		c, _ := Parse("0 9 * * * /cmd")
+
+		res := c.Next(time.Date(2022, 5, 10, 10, 0, 0, 0, loc))
+		assert.Equal(t, time.Date(2022, 5, 11, 9, 0, 0, 0, loc), res)
+		assert.Equal(t, loc, res.Location())
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// The schedule's own time zone
+	t.Run("Schedule_Location", func(t *testing.T) {
+		c, _ := Parse("CRON_TZ=Europe/London 0 9 * * * /cmd")
+
+		// 08:30 UTC is 09:30 in London during summer time
+		res := c.Next(date(2022, 6, 14, 8, 30))
+		assert.Equal(t, "Europe/London", res.Location().String())
+		assert.True(t, date(2022, 6, 15, 8, 0).Equal(res))
+
+		res = c.Prev(date(2022, 6, 14, 8, 30))
+		assert.True(t, date(2022, 6, 14, 8, 0).Equal(res))
+
+		// Winter time
+		res = c.Next(date(2022, 12, 14, 8, 30))
+		assert.True(t, date(2022, 12, 14, 9, 0).Equal(res))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_NextN(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Basic
+	t.Run("Basic", func(t *testing.T) {
+		c, _ := Parse("*/15 0 1,15 * 1-5 /usr/bin/find")
+		res := c.NextN(date(2022, 6, 14, 1, 0), 3)
+
+		expected := []time.Time{
+			date(2022, 6, 15, 0, 0),
+			date(2022, 6, 15, 0, 15),
+			date(2022, 6, 15, 0, 30),
+		}
+
+		assert.Equal(t, expected, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Never
+	t.Run("Never", func(t *testing.T) {
+		c, _ := Parse("0 0 31 4 * /cmd")
+		assert.Empty(t, c.NextN(date(2022, 1, 1, 0, 0), 3))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// No runs asked for
+	t.Run("Not_Positive", func(t *testing.T) {
+		for _, exp := range []string{"* * * * * /cmd", "@every 1h /cmd"} {
+			c, _ := Parse(exp)
+			for _, n := range []int{0, -1, -5} {
+				assert.Empty(t, c.NextN(date(2022, 1, 1, 0, 0), n))
+				assert.Empty(t, c.PrevN(date(2022, 1, 1, 0, 0), n))
+			}
+		}
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Prev(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		from     time.Time
+		expected time.Time
+	}{
+		{"Every_Minute", "* * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 10, 29)},
+		{"Inside_Minute", "* * * * * /cmd", time.Date(2022, 5, 10, 10, 30, 45, 0, time.UTC), date(2022, 5, 10, 10, 30)},
+		{"Step", "*/15 * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 10, 15)},
+		{"Previous_Hour", "45 * * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 10, 9, 45)},
+		{"Previous_Day", "0 12 * * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 5, 9, 12, 0)},
+		{"Previous_Month", "0 0 31 * * /cmd", date(2022, 5, 10, 10, 30), date(2022, 3, 31, 0, 0)},
+		{"Previous_Year", "0 0 1 12 * /cmd", date(2022, 5, 10, 10, 30), date(2021, 12, 1, 0, 0)},
+		{"Leap_Year", "0 0 29 2 * /cmd", date(2022, 1, 1, 0, 0), date(2020, 2, 29, 0, 0)},
+		{"Day_Of_Week", "30 8 * * 1-5 /cmd", date(2022, 5, 16, 8, 0), date(2022, 5, 13, 8, 30)},
+		{"Never", "0 0 30 2 * /cmd", date(2022, 1, 1, 0, 0), time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.Prev(tc.from))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_PrevN(t *testing.T) {
+	c, _ := Parse("0 */6 * * * /cmd")
+	res := c.PrevN(date(2022, 5, 10, 10, 30), 3)
+
+	expected := []time.Time{
+		date(2022, 5, 10, 6, 0),
+		date(2022, 5, 10, 0, 0),
+		date(2022, 5, 9, 18, 0),
+	}
+
+	assert.Equal(t, expected, res)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Between(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Inclusive
+	t.Run("Inclusive", func(t *testing.T) {
+		c, _ := Parse("*/20 * * * * /cmd")
+		res := c.Between(date(2022, 5, 10, 10, 0), date(2022, 5, 10, 11, 0))
+
+		expected := []time.Time{
+			date(2022, 5, 10, 10, 0),
+			date(2022, 5, 10, 10, 20),
+			date(2022, 5, 10, 10, 40),
+			date(2022, 5, 10, 11, 0),
+		}
+
+		assert.Equal(t, expected, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// None
+	t.Run("None", func(t *testing.T) {
+		c, _ := Parse("0 3 * * * /cmd")
+		assert.Empty(t, c.Between(date(2022, 5, 10, 10, 0), date(2022, 5, 10, 11, 0)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Reversed
+	t.Run("Reversed", func(t *testing.T) {
+		c, _ := Parse("* * * * * /cmd")
+		assert.Empty(t, c.Between(date(2022, 5, 10, 11, 0), date(2022, 5, 10, 10, 0)))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Matches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		opts     ParseOptions
+		at       time.Time
+		expected bool
+	}{
+		{"Match", "*/15 0 1,15 * 1-5 /cmd", ParseOptions{}, date(2022, 6, 15, 0, 15), true},
+		{"Wrong_Minute", "*/15 0 1,15 * 1-5 /cmd", ParseOptions{}, date(2022, 6, 15, 0, 16), false},
+		{"Wrong_Day", "*/15 0 1,15 * 1-5 /cmd", ParseOptions{}, date(2022, 6, 18, 0, 15), false},
+		{"Between_Seconds", "*/15 0 1,15 * 1-5 /cmd", ParseOptions{}, time.Date(2022, 6, 15, 0, 15, 30, 0, time.UTC), false},
+		{"Seconds", "*/20 * * * * *", ParseOptions{Dialect: DialectSeconds}, time.Date(2022, 6, 15, 0, 15, 40, 0, time.UTC), true},
+		{"Time_Zone", "CRON_TZ=Europe/London 0 9 * * * /cmd", ParseOptions{}, date(2022, 6, 15, 8, 0), true},
+		{"Time_Zone_Wall", "CRON_TZ=Europe/London 0 9 * * * /cmd", ParseOptions{}, date(2022, 6, 15, 9, 0), false},
+		{"DST_Shifted", "CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{}, date(2022, 3, 27, 1, 0), true},
+		{"DST_Skipped", "CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{DST: DSTWallClock}, date(2022, 3, 27, 1, 0), false},
+		{"DST_Repeated", "CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{DST: DSTWallClock}, date(2022, 10, 30, 1, 30), true},
+		{"DST_Once", "CRON_TZ=Europe/London 30 1 * * * /cmd", ParseOptions{}, date(2022, 10, 30, 1, 30), false},
+		{"Reboot", "@reboot /cmd", ParseOptions{}, date(2022, 6, 15, 0, 0), false},
+		{"Every", "@every 1h /cmd", ParseOptions{}, date(2022, 6, 15, 0, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.exp, tc.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.Matches(tc.at))
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Macros(t *testing.T) {
+	from := time.Date(2022, 5, 10, 10, 30, 15, 500, time.UTC)
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Reboot
+	t.Run("Reboot", func(t *testing.T) {
+		c, _ := Parse("@reboot /cmd")
+		assert.True(t, c.Next(from).IsZero())
+		assert.True(t, c.Prev(from).IsZero())
+		assert.Empty(t, c.Between(from, from.Add(time.Hour)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Every
+	t.Run("Every", func(t *testing.T) {
+		c, _ := Parse("@every 20m /cmd")
+		assert.Equal(t, time.Date(2022, 5, 10, 10, 50, 15, 0, time.UTC), c.Next(from))
+		assert.Equal(t, time.Date(2022, 5, 10, 10, 10, 15, 0, time.UTC), c.Prev(from))
+
+		start := date(2022, 5, 10, 10, 0)
+		expected := []time.Time{
+			date(2022, 5, 10, 10, 20),
+			date(2022, 5, 10, 10, 40),
+			date(2022, 5, 10, 11, 0),
+		}
+		assert.Equal(t, expected, c.Between(start, start.Add(time.Hour)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Daily
+	t.Run("Daily", func(t *testing.T) {
+		c, _ := Parse("@daily /cmd")
+		assert.Equal(t, date(2022, 5, 11, 0, 0), c.Next(from))
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Schedule_Dialects(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Seconds
+	t.Run("Seconds", func(t *testing.T) {
+		c, _ := ParseWithOptions("*/20 * * * * *", ParseOptions{Dialect: DialectSeconds})
+		from := time.Date(2022, 5, 10, 10, 30, 45, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2022, 5, 10, 10, 31, 0, 0, time.UTC), c.Next(from))
+		assert.Equal(t, time.Date(2022, 5, 10, 10, 30, 40, 0, time.UTC), c.Prev(from))
+		assert.Equal(t, time.Date(2022, 5, 10, 10, 30, 20, 0, time.UTC), c.Prev(from.Add(-5*time.Second)))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Seconds across a day
+	t.Run("Seconds_Next_Day", func(t *testing.T) {
+		c, _ := ParseWithOptions("30 0 9 * * *", ParseOptions{Dialect: DialectSeconds})
+		from := time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2022, 5, 11, 9, 0, 30, 0, time.UTC), c.Next(from))
+		assert.Equal(t, time.Date(2022, 5, 10, 9, 0, 30, 0, time.UTC), c.Prev(from))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Year
+	t.Run("Year", func(t *testing.T) {
+		c, _ := Parse("0 0 12 1 1 ? 2030,2090")
+
+		assert.Equal(t, date(2030, 1, 1, 12, 0), c.Next(date(2022, 1, 1, 0, 0)))
+		assert.Equal(t, date(2090, 1, 1, 12, 0), c.Next(date(2030, 1, 1, 12, 0)))
+		assert.True(t, c.Next(date(2090, 1, 1, 12, 0)).IsZero())
+		assert.Equal(t, date(2030, 1, 1, 12, 0), c.Prev(date(2090, 1, 1, 0, 0)))
+		assert.True(t, c.Prev(date(2030, 1, 1, 0, 0)).IsZero())
+	})
+}
@@ -0,0 +1,971 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// OnCalendar format (systemd.time)
+//
+//	[weekday] [year-month-day] [hour:minute[:second]] [time zone]
+//
+//	Mon..Fri *-*-* 09:00:00 Europe/London
+//
+//	, == separate items (ex Mon,Wed or 01,15)
+//	.. == range (ex Mon..Fri or 09..17)
+//	/ == repeat from a value (ex 00/15 or *:0/15)
+//	~ == day counted back from the last of the month (ex *-*~01)
+//
+// A missing date is every day and a missing time is midnight. The
+// weekday and the date must both match. The shorthands minutely,
+// hourly, daily, weekly, monthly, quarterly, semiannually, yearly and
+// annually can be used in place of the spec
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdDays are the weekday names systemd writes, from Sunday
+var systemdDays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// systemdShorthands maps each OnCalendar shorthand to its spec
+var systemdShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+}
+
+// systemdValues are the values each part of a spec accepts, for hints
+var systemdValues = map[string]string{
+	"weekday": "Mon-Sun",
+	"year":    "1970-2099",
+	"month":   "01-12",
+	"day":     "01-31",
+	"hour":    "00-23",
+	"minute":  "00-59",
+	"second":  "00-59",
+}
+
+// A date holds only date characters, which tells it apart from a time
+// zone
+var systemdDateRegex = regexp.MustCompile(`^[\d*.,/~-]+$`)
+
+// systemdFormat is the hint for a spec that is not in the OnCalendar
+// format
+const systemdFormat = "use [weekday] [year-month-day] [hour:minute[:second]] [time zone] (ex Mon..Fri *-*-* 09:00)"
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// OnCalendar returns the systemd OnCalendar= specs the schedule runs
+// on (ex Mon..Fri *-*-* 09:00:00)
+//
+// A spec's weekday and date must both match, so a schedule that runs
+// on days matching either day field is a spec for each of them, which
+// one timer can hold. Schedules with no equivalent return an error
+// saying why, rather than the nearest spec
+func (c Cron) OnCalendar() ([]string, error) {
+	switch c.Kind {
+	case KindReboot:
+		return nil, fmt.Errorf("systemd - @reboot has no calendar time, use OnBootSec= instead")
+	case KindInterval:
+		return nil, fmt.Errorf("systemd - @every has no calendar time, use OnUnitActiveSec=%s instead", c.Interval)
+	}
+
+	days, err := c.systemdDays()
+	if err != nil {
+		return nil, err
+	}
+
+	year := "*"
+	if len(c.Year) > 0 {
+		year = systemdList(c.Year, defaultYearSlice, "%04d")
+	}
+	month := systemdList(c.Month, defaultMonthSlice, "%02d")
+
+	seconds, _ := c.seconds()
+	clock := systemdList(c.Hour, defaultHourSlice, "%02d") + ":" +
+		systemdList(c.Minute, defaultMinuteSlice, "%02d") + ":" +
+		systemdList(seconds, defaultMinuteSlice, "%02d")
+
+	zone := ""
+	if c.Location != nil {
+		zone = " " + c.Location.String()
+	}
+
+	specs := make([]string, len(days))
+	for i, d := range days {
+		specs[i] = year + "-" + month + d.date + " " + clock + zone
+		if d.weekdays != "" {
+			specs[i] = d.weekdays + " " + specs[i]
+		}
+	}
+
+	return specs, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdDay is the weekday and day of month part of a spec. The date
+// starts with its separator (ex -01,15 or ~01)
+type systemdDay struct {
+	weekdays string
+	date     string
+}
+
+// systemdDays returns the day parts of the specs the schedule needs
+func (c Cron) systemdDays() ([]systemdDay, error) {
+	var dom, dow []systemdDay
+	domUsed, dowUsed := c.dayFields()
+
+	if domUsed {
+		if len(c.DayOfMonth) > 0 {
+			dom = append(dom, systemdDay{date: "-" + systemdList(c.DayOfMonth, defaultDomSlice, "%02d")})
+		}
+		var last IntSlice
+		for _, r := range c.DayOfMonthRules {
+			if r.Kind != LastDayOfMonth {
+				return nil, fmt.Errorf("systemd - day of month %s has no equivalent, as systemd has no nearest weekday", r)
+			}
+			last = append(last, r.N+1)
+		}
+		if len(last) > 0 {
+			dom = append(dom, systemdDay{date: "~" + systemdLastList(last)})
+		}
+	}
+
+	if dowUsed {
+		if len(c.DayOfWeek) > 0 {
+			dow = append(dow, systemdDay{weekdays: systemdWeekdays(c.DayOfWeek), date: "-*"})
+		}
+		for _, r := range c.DayOfWeekRules {
+			switch r.Kind {
+			case LastDayOfWeek:
+				dow = append(dow, systemdDay{weekdays: systemdDays[r.Day], date: "~07/1"})
+			case NthDayOfWeek:
+				first, last := (r.N-1)*7+1, r.N*7
+				if last > 31 {
+					last = 31
+				}
+				dow = append(dow, systemdDay{weekdays: systemdDays[r.Day], date: fmt.Sprintf("-%02d..%02d", first, last)})
+			}
+		}
+	}
+
+	switch {
+	case len(dom) == 0 && len(dow) == 0:
+		return []systemdDay{{date: "-*"}}, nil
+	case len(dow) == 0:
+		return dom, nil
+	case len(dom) == 0:
+		return dow, nil
+	case c.Days.Either():
+		return append(dom, dow...), nil
+	}
+
+	// Both fields must match, so each weekday is paired with each day
+	// of month
+	var result []systemdDay
+	for _, w := range dow {
+		if w.date != "-*" {
+			return nil, fmt.Errorf("systemd - day of week %s has no equivalent with a day of month that must also match, as both set the date", c.Source.DayOfWeek)
+		}
+		for _, m := range dom {
+			result = append(result, systemdDay{weekdays: w.weekdays, date: m.date})
+		}
+	}
+
+	return result, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdList writes values as a spec component: * for all of them, a
+// repetition (ex 00/15) or items with ranges of 3 or more (ex 01..05)
+func systemdList(values, all IntSlice, format string) string {
+	if len(values) == len(all) {
+		return "*"
+	}
+
+	// Repetition to the end of the values
+	if len(values) > 2 {
+		step := values[1] - values[0]
+		ok := step > 1 && values[len(values)-1]+step > all[len(all)-1]
+		for i := 2; ok && i < len(values); i++ {
+			ok = values[i]-values[i-1] == step
+		}
+		if ok {
+			return fmt.Sprintf(format+"/%d", values[0], step)
+		}
+	}
+
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			items = append(items, fmt.Sprintf(format+".."+format, values[i], values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, fmt.Sprintf(format, values[k]))
+			}
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// systemdLastList writes days counted back from the end of the month
+// (1 is the last day) as a spec component, repeating down to the last
+// day where it can (ex 07/1 for the last 7 days)
+func systemdLastList(values IntSlice) string {
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+	step := 0
+	if len(values) > 1 {
+		step = values[0] - values[1]
+	}
+	ok := len(values) > 2 && values[len(values)-1] <= step
+	for i := 2; ok && i < len(values); i++ {
+		ok = values[i-1]-values[i] == step
+	}
+	if ok {
+		return fmt.Sprintf("%02d/%d", values[0], step)
+	}
+
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[len(values)-1-i] = fmt.Sprintf("%02d", v)
+	}
+	return strings.Join(items, ",")
+}
+
+// systemdWeekdays writes days of the week (0 - 6 from Sunday) as
+// names, with ranges of 3 or more from Monday (ex Mon..Fri,Sun)
+func systemdWeekdays(values IntSlice) string {
+	// Days from Monday
+	days := make([]int, len(values))
+	for i, v := range values {
+		days[i] = (v + 6) % 7
+	}
+	sort.Ints(days)
+
+	var items []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == days[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			items = append(items, systemdDays[(days[i]+1)%7]+".."+systemdDays[(days[j]+1)%7])
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, systemdDays[(days[k]+1)%7])
+			}
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// SystemdUnits returns a .timer and .service unit pair that run the
+// schedule's command, for units named name (ex name.timer)
+//
+// The command is run by /bin/sh, as cron does. Schedules OnCalendar
+// can't express and commands using cron's % input are an error
+func (c Cron) SystemdUnits(name string) (timer, service string, err error) {
+	specs, err := c.OnCalendar()
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.TrimSpace(c.Command) == "" {
+		return "", "", fmt.Errorf("systemd - the expression has no command to run")
+	}
+
+	command, err := systemdCommand(c.Command)
+	if err != nil {
+		return "", "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[Unit]\n")
+	fmt.Fprintf(&sb, "Description=Run %s.service on the schedule %s\n", name, systemdEscape(c.scheduleText()))
+	sb.WriteString("\n[Timer]\n")
+	for _, spec := range specs {
+		sb.WriteString("OnCalendar=" + spec + "\n")
+	}
+	sb.WriteString("# Start on time, as cron does, instead of up to a minute late\n")
+	sb.WriteString("AccuracySec=1s\n")
+	sb.WriteString("\n[Install]\n")
+	sb.WriteString("WantedBy=timers.target\n")
+	timer = sb.String()
+
+	sb.Reset()
+	sb.WriteString("[Unit]\n")
+	sb.WriteString("Description=" + systemdEscape(command) + "\n")
+	sb.WriteString("\n[Service]\n")
+	sb.WriteString("Type=oneshot\n")
+	if c.User != "" {
+		sb.WriteString("User=" + c.User + "\n")
+	}
+	// ExecStart= also expands variables ($)
+	quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$").Replace(command)
+	sb.WriteString("ExecStart=/bin/sh -c \"" + systemdEscape(quoted) + "\"\n")
+	service = sb.String()
+
+	return timer, service, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// scheduleText returns the expression without its user or command
+func (c Cron) scheduleText() string {
+	text := strings.TrimSpace(c.Original)
+	if c.Command != "" {
+		text = strings.TrimSpace(strings.TrimSuffix(text, c.Command))
+	}
+	if c.User != "" {
+		text = strings.TrimSpace(strings.TrimSuffix(text, c.User))
+	}
+	return text
+}
+
+// systemdCommand returns a crontab command as systemd runs it, with
+// cron's \% as %. Cron turns an unescaped % into a newline and sends
+// the rest of the command as input, which a service can't do
+func systemdCommand(command string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(command); i++ {
+		switch {
+		case command[i] == '\\' && i+1 < len(command) && command[i+1] == '%':
+			sb.WriteByte('%')
+			i++
+		case command[i] == '%':
+			return "", fmt.Errorf("systemd - the command has an unescaped %%, which cron turns into a newline and sends the rest of as input")
+		default:
+			sb.WriteByte(command[i])
+		}
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// systemdEscape escapes the specifiers (ex %n) systemd expands in
+// unit settings
+func systemdEscape(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdPart is a part of a spec and its byte offset in the spec
+type systemdPart struct {
+	name   string
+	text   string
+	column int
+}
+
+// parseOnCalendar parses a systemd OnCalendar spec into a Cron, with
+// the fields written as cron fields in its Source
+//
+// See the OnCalendar format above
+func parseOnCalendar(spec string, opts ParseOptions) (*Cron, error) {
+	parts := strings.Fields(spec)
+	offsets := fieldOffsets(spec)
+
+	if len(parts) == 0 {
+		return nil, missingError(spec, systemdFormat)
+	}
+
+	// A shorthand stands for the spec's parts, all at its offset
+	if expanded, ok := systemdShorthands[strings.ToLower(parts[0])]; ok {
+		fields := strings.Fields(expanded)
+		at := make([]int, len(fields))
+		for i := range at {
+			at[i] = offsets[0]
+		}
+		parts = append(fields, parts[1:]...)
+		offsets = append(at, offsets[1:]...)
+	}
+
+	var (
+		weekday *systemdPart
+		date    = []systemdPart{{"year", "*", 0}, {"month", "*", 0}, {"day", "*", 0}}
+		clock   = []systemdPart{{"hour", "00", 0}, {"minute", "00", 0}, {"second", "00", 0}}
+		lastDay bool
+		loc     *time.Location
+	)
+
+	// Time zone
+	last := len(parts) - 1
+	if last > 0 && !strings.Contains(parts[last], ":") && !systemdDateRegex.MatchString(parts[last]) {
+		var err error
+		if loc, err = ParseLocation(parts[last]); err != nil {
+			return nil, timeZoneError(spec, offsets[last], parts[last])
+		}
+		parts, offsets = parts[:last], offsets[:last]
+	}
+
+	i := 0
+	if len(parts) > 0 && !systemdDateRegex.MatchString(parts[0]) && !strings.Contains(parts[0], ":") {
+		weekday = &systemdPart{"weekday", parts[0], offsets[0]}
+		i++
+	}
+
+	// Date
+	if i < len(parts) && !strings.Contains(parts[i], ":") {
+		var pieces []systemdPart
+		text, column := parts[i], offsets[i]
+
+		day := ""
+		if n := strings.Index(text, "~"); n >= 0 {
+			day, text = text[n+1:], text[:n]
+			lastDay = true
+		}
+
+		offset := 0
+		for _, piece := range strings.Split(text, "-") {
+			pieces = append(pieces, systemdPart{text: piece, column: column + offset})
+			offset += len(piece) + 1
+		}
+		if lastDay {
+			pieces = append(pieces, systemdPart{text: day, column: column + len(text) + 1})
+		}
+
+		switch len(pieces) {
+		case 2:
+			date[1].text, date[1].column = pieces[0].text, pieces[0].column
+			date[2].text, date[2].column = pieces[1].text, pieces[1].column
+		case 3:
+			for n := range date {
+				date[n].text, date[n].column = pieces[n].text, pieces[n].column
+			}
+		default:
+			return nil, systemdError(spec, "date", parts[i], offsets[i], "use year-month-day or month-day (ex *-*-01 or 01-15)")
+		}
+		i++
+	}
+
+	// Time
+	if i < len(parts) && strings.Contains(parts[i], ":") {
+		text, column := parts[i], offsets[i]
+		pieces := strings.Split(text, ":")
+		if len(pieces) > 3 {
+			return nil, systemdError(spec, "time", text, column, "use hour:minute or hour:minute:second (ex 09:00)")
+		}
+
+		offset := 0
+		for n, piece := range pieces {
+			clock[n].text, clock[n].column = piece, column+offset
+			offset += len(piece) + 1
+		}
+		i++
+	}
+
+	if i < len(parts) {
+		return nil, systemdError(spec, "systemd", parts[i], offsets[i], systemdFormat)
+	}
+
+	// Every part as a cron field
+	fields := map[string]string{"weekday": "*"}
+	for _, p := range append(date, clock...) {
+		var (
+			field string
+			ok    bool
+		)
+
+		switch {
+		case p.name == "second" && strings.Contains(p.text, "."):
+			return nil, &ParseError{
+				Expression: spec,
+				Field:      "second",
+				Column:     p.column,
+				Token:      p.text,
+				Hint:       "cron runs on whole seconds",
+				Err:        fmt.Errorf("fractions of a second are not supported"),
+			}
+		case p.name == "day" && lastDay:
+			field, ok = systemdLastDays(p.text)
+		default:
+			field, ok = systemdField(p.text, systemdRanges[p.name])
+		}
+
+		if !ok {
+			return nil, systemdPartError(spec, p)
+		}
+		fields[p.name] = field
+	}
+
+	if weekday != nil {
+		field, ok := systemdWeekdayField(weekday.text)
+		if !ok {
+			return nil, systemdPartError(spec, *weekday)
+		}
+		fields["weekday"] = field
+	}
+
+	// Year
+	var year IntSlice
+	if fields["year"] != "*" {
+		var err error
+		if year, err = parseSegment(fields["year"], defaultYearSlice); err != nil {
+			return nil, systemdPartError(spec, date[0])
+		}
+	}
+
+	// The other fields are parsed as a cron expression, with both day
+	// fields having to match as they do in systemd
+	exp := strings.Join([]string{fields["second"], fields["minute"], fields["hour"], fields["day"], fields["month"], fields["weekday"]}, " ")
+	c, err := ParseWithOptions(exp, ParseOptions{Dialect: DialectSeconds, DayMatch: DayMatchAnd, DST: opts.DST})
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			for _, p := range append(append(date, clock...), systemdPart{name: "weekday"}) {
+				if systemdFieldNames[p.name] == pe.Field {
+					if p.name == "weekday" && weekday != nil {
+						p = *weekday
+					}
+					return nil, systemdPartError(spec, p)
+				}
+			}
+		}
+		return nil, err
+	}
+
+	c.Original = spec
+	c.Source.Dialect = DialectSystemd
+	c.Source.Year = fields["year"]
+	c.Year = year
+	c.Location = loc
+
+	return c, nil
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdRanges are the values of each part of a spec
+var systemdRanges = map[string]IntSlice{
+	"year":   defaultYearSlice,
+	"month":  defaultMonthSlice,
+	"day":    defaultDomSlice,
+	"hour":   defaultHourSlice,
+	"minute": defaultMinuteSlice,
+	"second": defaultMinuteSlice,
+}
+
+// systemdFieldNames maps each part of a spec to the cron field it
+// becomes
+var systemdFieldNames = map[string]string{
+	"weekday": "day of week",
+	"month":   "month",
+	"day":     "day of month",
+	"hour":    "hour",
+	"minute":  "minute",
+	"second":  "second",
+}
+
+// systemdField turns a part of a spec into a cron field (ex 00/15 to
+// 0-59/15 or 01..05 to 1-5), checking it holds numbers within all
+func systemdField(text string, all IntSlice) (string, bool) {
+	if text == "*" {
+		return "*", true
+	}
+
+	items := strings.Split(text, ",")
+	for i, item := range items {
+		values, step := item, ""
+		if n := strings.Index(item, "/"); n >= 0 {
+			values, step = item[:n], item[n:]
+		}
+
+		values = strings.Replace(values, "..", "-", 1)
+		if step != "" && values != "*" && !strings.Contains(values, "-") {
+			values += "-" + strconv.Itoa(all[len(all)-1])
+		}
+		items[i] = values + step
+	}
+
+	field := strings.Join(items, ",")
+	if _, err := parseSegment(field, all); err != nil {
+		return "", false
+	}
+
+	return field, true
+}
+
+// systemdWeekdayField turns a weekday part into a cron day of week
+// field (ex Mon..Fri to 1-5). Weeks start on Monday, so Sunday is 7 in
+// a range and can't start one that goes on to other days (ex Sun..Mon)
+func systemdWeekdayField(text string) (string, bool) {
+	day := func(name string) (int, bool) {
+		for n, d := range systemdDays {
+			full := time.Weekday(n).String()
+			if strings.EqualFold(name, d) || strings.EqualFold(name, full) {
+				return n, true
+			}
+		}
+		return 0, false
+	}
+
+	items := strings.Split(text, ",")
+	for i, item := range items {
+		names := strings.SplitN(item, "..", 2)
+
+		first, ok := day(names[0])
+		if !ok {
+			return "", false
+		}
+		if len(names) == 1 {
+			items[i] = strconv.Itoa(first)
+			continue
+		}
+
+		last, ok := day(names[1])
+		if !ok {
+			return "", false
+		}
+		if first == 0 {
+			first = 7
+		}
+		if last == 0 {
+			last = 7
+		}
+		if last < first {
+			return "", false
+		}
+		items[i] = fmt.Sprintf("%d-%d", first, last)
+	}
+
+	return strings.Join(items, ","), true
+}
+
+// systemdLastDays turns a day part counted back from the end of the
+// month into L rules (ex 01..03 to L,L-1,L-2). A repetition counts
+// down to the last day (ex 07/1 is the last 7 days)
+func systemdLastDays(text string) (string, bool) {
+	var items []string
+	seen := map[int]bool{}
+
+	for _, item := range strings.Split(text, ",") {
+		values, step := item, ""
+		if n := strings.Index(item, "/"); n >= 0 {
+			values, step = item[:n], item[n+1:]
+		}
+
+		var days []int
+		bounds := strings.SplitN(values, "..", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 1 || first > 31 {
+			return "", false
+		}
+
+		switch {
+		case len(bounds) == 2 && step == "":
+			last, err := strconv.Atoi(bounds[1])
+			if err != nil || last < first || last > 31 {
+				return "", false
+			}
+			for d := first; d <= last; d++ {
+				days = append(days, d)
+			}
+		case len(bounds) == 1 && step != "":
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return "", false
+			}
+			for d := first; d >= 1; d -= n {
+				days = append(days, d)
+			}
+		case len(bounds) == 1:
+			days = append(days, first)
+		default:
+			return "", false
+		}
+
+		for _, d := range days {
+			if seen[d] {
+				continue
+			}
+			seen[d] = true
+
+			if d == 1 {
+				items = append(items, "L")
+			} else {
+				items = append(items, fmt.Sprintf("L-%d", d-1))
+			}
+		}
+	}
+
+	return strings.Join(items, ","), true
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// systemdPartError returns a ParseError for a part of a spec that
+// could not be parsed
+func systemdPartError(spec string, p systemdPart) *ParseError {
+	hint := fmt.Sprintf("use %s, a range (ex 01..05) or a repetition (ex 00/15), separated by commas", systemdValues[p.name])
+	switch p.name {
+	case "weekday":
+		hint = "use Mon-Sun or a range (ex Mon..Fri), separated by commas"
+	case "day":
+		if strings.Contains(spec[:p.column], "~") {
+			hint = "use days back from the last of the month, from 01 (ex ~01 or ~07/1)"
+		}
+	}
+
+	return systemdError(spec, p.name, p.text, p.column, hint)
+}
+
+// systemdError returns a ParseError for a token of a spec
+func systemdError(spec, name, token string, column int, hint string) *ParseError {
+	return &ParseError{
+		Expression: spec,
+		Field:      name,
+		Column:     column,
+		Token:      token,
+		Hint:       hint,
+		Err:        errInvalid,
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Expression returns the schedule as a cron expression, without its
+// user or command (ex CRON_TZ=Europe/London 0 9 * * MON-FRI)
+//
+// Seconds other than 0 are written in the seconds dialect and years
+// in Quartz. Cron runs on days matching either day field, so a
+// schedule where both are restricted and must match is an error
+func (c Cron) Expression() (string, error) {
+	switch c.Kind {
+	case KindReboot:
+		return "@reboot", nil
+	case KindInterval:
+		return "@every " + c.Interval.String(), nil
+	}
+
+	quartz := len(c.Year) > 0
+	seconds, _ := c.seconds()
+	second := cronList(seconds, defaultMinuteSlice, nil)
+
+	// Days of the week are names, which every dialect reads the same,
+	// but rules are numbers, which Quartz counts from 1
+	first := 0
+	if quartz {
+		first = 1
+	}
+
+	dom, dow, ok := c.cronDays(first, systemdDays)
+	if !ok {
+		return "", fmt.Errorf("cron - days matching both day of month and day of week have no equivalent, as cron runs on days matching either")
+	}
+
+	fields := []string{
+		cronList(c.Minute, defaultMinuteSlice, nil),
+		cronList(c.Hour, defaultHourSlice, nil),
+		dom,
+		cronList(c.Month, defaultMonthSlice, nil),
+		dow,
+	}
+
+	switch {
+	case quartz && dom != "*" && dow != "*":
+		return "", fmt.Errorf("cron - days limited by both day of month and day of week have no Quartz equivalent, as one of them must be ?")
+	case quartz:
+		if dow != "*" {
+			fields[2] = "?"
+		} else {
+			fields[4] = "?"
+		}
+		fields = append(append([]string{second}, fields...), cronList(c.Year, defaultYearSlice, nil))
+	case second != "0":
+		fields = append([]string{second}, fields...)
+	}
+
+	exp := strings.Join(fields, " ")
+	if c.Location != nil {
+		exp = "CRON_TZ=" + c.Location.String() + " " + exp
+	}
+
+	return exp, nil
+}
+
+// cronDays returns the day of month and day of week fields of the
+// schedule, with days of the week as names when given, or numbered
+// from first for Sunday. Rules are numbered from first
+//
+// Cron runs on days matching either field when neither starts with *,
+// so days that only need to match one are written without one (ex
+// 1-31/2 for */2). Days that must match both are only ok when one of
+// the fields starts with * (ex */2 MON) or they are a day rule (ex
+// MON#1)
+func (c Cron) cronDays(first int, names []string) (dom, dow string, ok bool) {
+	domUsed, dowUsed := c.dayFields()
+	if domUsed && dowUsed && !c.Days.Either() {
+		if rule, ok := c.weekdayRule(); ok {
+			domUsed = false
+			c.DayOfWeek, c.DayOfWeekRules = nil, DayRules{rule}
+		}
+	}
+
+	// Days of the week written as a range, for a field starting with *
+	weekRange := strconv.Itoa(first) + "-" + strconv.Itoa(first+6)
+	if names != nil {
+		weekRange = strings.ToUpper(names[0] + "-" + names[6])
+	}
+
+	dom, dow = "*", "*"
+	if domUsed {
+		var items []string
+		if len(c.DayOfMonth) > 0 {
+			items = append(items, cronList(c.DayOfMonth, defaultDomSlice, nil))
+		}
+		for _, r := range c.DayOfMonthRules {
+			items = append(items, r.String())
+		}
+		dom = strings.Join(items, ",")
+	}
+
+	if dowUsed {
+		var items []string
+		switch {
+		case len(c.DayOfWeek) > 0 && names != nil:
+			items = append(items, cronList(c.DayOfWeek, defaultDowSlice, names))
+		case len(c.DayOfWeek) > 0:
+			days := make(IntSlice, len(c.DayOfWeek))
+			for i, d := range c.DayOfWeek {
+				days[i] = d + first
+			}
+			items = append(items, cronList(days, defaultMinuteSlice[first:first+7], nil))
+		}
+		for _, r := range c.DayOfWeekRules {
+			r.Day += first
+			items = append(items, r.String())
+		}
+		dow = strings.Join(items, ",")
+	}
+
+	if !domUsed || !dowUsed {
+		return dom, dow, true
+	}
+
+	starred := strings.HasPrefix(dom, "*") || strings.HasPrefix(dow, "*")
+	switch {
+	case c.Days.Either():
+		dom = strings.Replace(dom, "*", "1-31", 1)
+		dow = strings.Replace(dow, "*", weekRange, 1)
+	case !starred:
+		return "", "", false
+	}
+
+	return dom, dow, true
+}
+
+// weekdayRule returns the day rule that is the same as a single day of
+// the week in one week of the month, which is days 1 - 7, 8 - 14 and
+// so on, or the last 7 days (ex Mon with 1 - 7 is MON#1)
+func (c Cron) weekdayRule() (DayRule, bool) {
+	if len(c.DayOfWeek) != 1 || len(c.DayOfWeekRules) > 0 {
+		return DayRule{}, false
+	}
+	day := c.DayOfWeek[0]
+
+	if len(c.DayOfMonth) == 0 && len(c.DayOfMonthRules) == 7 {
+		last := map[int]bool{}
+		for _, r := range c.DayOfMonthRules {
+			if r.Kind == LastDayOfMonth {
+				last[r.N] = true
+			}
+		}
+		for n := 0; n < 7; n++ {
+			if !last[n] {
+				return DayRule{}, false
+			}
+		}
+		return DayRule{Kind: LastDayOfWeek, Day: day}, true
+	}
+
+	if len(c.DayOfMonthRules) > 0 || len(c.DayOfMonth) == 0 {
+		return DayRule{}, false
+	}
+
+	// Days are in order without repeats, so the first and last tell if
+	// they are a whole week
+	first, last := c.DayOfMonth[0], c.DayOfMonth[len(c.DayOfMonth)-1]
+	week := first/7 + 1
+	if first%7 != 1 || last-first != len(c.DayOfMonth)-1 || (last != first+6 && !(week == 5 && last == 31)) {
+		return DayRule{}, false
+	}
+
+	return DayRule{Kind: NthDayOfWeek, Day: day, N: week}, true
+}
+
+// cronList writes values as a cron field: * for all of them, a step
+// (ex */15 or 5-59/15) or items with ranges of 3 or more (ex 1-5).
+// Values are written as names, in upper case, when given
+func cronList(values, all IntSlice, names []string) string {
+	if len(values) == len(all) {
+		return "*"
+	}
+
+	value := func(v int) string {
+		if names != nil {
+			return strings.ToUpper(names[v])
+		}
+		return strconv.Itoa(v)
+	}
+
+	// Step to the end of the values
+	if len(values) > 2 {
+		step := values[1] - values[0]
+		ok := step > 1 && values[len(values)-1]+step > all[len(all)-1]
+		for i := 2; ok && i < len(values); i++ {
+			ok = values[i]-values[i-1] == step
+		}
+		switch {
+		case ok && values[0] == all[0]:
+			return fmt.Sprintf("*/%d", step)
+		case ok:
+			return fmt.Sprintf("%s-%s/%d", value(values[0]), value(all[len(all)-1]), step)
+		}
+	}
+
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 {
+			items = append(items, value(values[i])+"-"+value(values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, value(values[k]))
+			}
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
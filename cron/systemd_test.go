@@ -0,0 +1,342 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Systemd_OnCalendar(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		exp      string
+		expected []string
+	}{
+		{"Weekdays", "0 9 * * 1-5 /cmd", []string{"Mon..Fri *-*-* 09:00:00"}},
+		{"Repetition", "*/15 * * * * /cmd", []string{"*-*-* *:00/15:00"}},
+		{"List", "0,30 8-18 * * * /cmd", []string{"*-*-* 08..18:00,30:00"}},
+		{"Weekend", "0 10 * * 6,0 /cmd", []string{"Sat,Sun *-*-* 10:00:00"}},
+		{"Months", "0 0 1 */3 * /cmd", []string{"*-01/3-01 00:00:00"}},
+		{"Seconds", "30 0 12 * * ?", []string{"*-*-* 12:00:30"}},
+		{"Year", "0 0 12 1 1 ? 2030", []string{"2030-01-01 12:00:00"}},
+		{"Time_Zone", "CRON_TZ=Europe/London 30 2 * * * /cmd", []string{"*-*-* 02:30:00 Europe/London"}},
+		{"Macro", "@weekly /cmd", []string{"Sun *-*-* 00:00:00"}},
+		{"Last_Day", "0 0 L * * /cmd", []string{"*-*~01 00:00:00"}},
+		{"Last_Days", "0 0 L-2,L-1,L * * /cmd", []string{"*-*~03/1 00:00:00"}},
+		{"Last_Weekday", "0 0 12 ? * 5L", []string{"Thu *-*~07/1 12:00:00"}},
+		{"Nth_Weekday", "0 0 12 ? * MON#2", []string{"Mon *-*-08..14 12:00:00"}},
+		{"Fifth_Weekday", "0 0 12 ? * FRI#5", []string{"Fri *-*-29..31 12:00:00"}},
+		{"Either_Day", "0 0 1,15 * 5 /cmd", []string{"*-*-01,15 00:00:00", "Fri *-*-* 00:00:00"}},
+		{"Step_Weekday", "0 0 * * */2 /cmd", []string{"Tue,Thu,Sat,Sun *-*-* 00:00:00"}},
+		{"Step_Day", "0 0 */2 * * /cmd", []string{"*-*-01/2 00:00:00"}},
+		{"Step_Day_Weekday", "0 0 */2 * MON /cmd", []string{"Mon *-*-01/2 00:00:00"}},
+		{"Whole_Day_Either", "0 0 1-31 * MON /cmd", []string{"*-*-* 00:00:00"}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			res, err := c.OnCalendar()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Both day fields must match
+	t.Run("Day_Match_And", func(t *testing.T) {
+		c, err := ParseWithOptions("0 0 1-7 * 1 /cmd", ParseOptions{DayMatch: DayMatchAnd})
+		assert.Nil(t, err)
+
+		res, err := c.OnCalendar()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Mon *-*-01..07 00:00:00"}, res)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Schedules without an equivalent
+	invalidTestCases := []struct {
+		name string
+		exp  string
+		opts ParseOptions
+		err  string
+	}{
+		{"Reboot", "@reboot /cmd", ParseOptions{}, "systemd - @reboot has no calendar time, use OnBootSec= instead"},
+		{"Every", "@every 90m /cmd", ParseOptions{}, "systemd - @every has no calendar time, use OnUnitActiveSec=1h30m0s instead"},
+		{"Nearest_Weekday", "0 0 15W * * /cmd", ParseOptions{}, "systemd - day of month 15W has no equivalent, as systemd has no nearest weekday"},
+		{"Last_Weekday_Of_Month", "0 0 LW * * /cmd", ParseOptions{}, "systemd - day of month LW has no equivalent, as systemd has no nearest weekday"},
+		{"Rule_And_Day", "0 0 1-7 * 5L /cmd", ParseOptions{DayMatch: DayMatchAnd}, "systemd - day of week 5L has no equivalent with a day of month that must also match, as both set the date"},
+	}
+
+	for _, tc := range invalidTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.exp, tc.opts)
+			assert.Nil(t, err)
+
+			_, err = c.OnCalendar()
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Systemd_SystemdUnits(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// A timer and the service it starts
+	t.Run("Units", func(t *testing.T) {
+		c, err := ParseWithOptions(`0 9 * * 1-5 backup echo "100\% $HOME" > /tmp/log`, ParseOptions{System: true})
+		assert.Nil(t, err)
+
+		timer, service, err := c.SystemdUnits("report")
+		assert.Nil(t, err)
+		assert.Equal(t, `[Unit]
+Description=Run report.service on the schedule 0 9 * * 1-5
+
+[Timer]
+OnCalendar=Mon..Fri *-*-* 09:00:00
+# Start on time, as cron does, instead of up to a minute late
+AccuracySec=1s
+
+[Install]
+WantedBy=timers.target
+`, timer)
+		assert.Equal(t, `[Unit]
+Description=echo "100%% $HOME" > /tmp/log
+
+[Service]
+Type=oneshot
+User=backup
+ExecStart=/bin/sh -c "echo \"100%% $$HOME\" > /tmp/log"
+`, service)
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid
+	invalidTestCases := []struct {
+		name string
+		exp  string
+		err  string
+	}{
+		{"Reboot", "@reboot /cmd", "systemd - @reboot has no calendar time, use OnBootSec= instead"},
+		{"No_Command", "0 0 12 * * ?", "systemd - the expression has no command to run"},
+		{"Percent", "0 0 * * * date +%F", "systemd - the command has an unescaped %, which cron turns into a newline and sends the rest of as input"},
+	}
+
+	for _, tc := range invalidTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.exp)
+			assert.Nil(t, err)
+
+			_, _, err = c.SystemdUnits("job")
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Systemd_Parse(t *testing.T) {
+	systemd := ParseOptions{Dialect: DialectSystemd}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		spec     string
+		expected Source
+	}{
+		{"Weekdays", "Mon..Fri 09:00", Source{DialectSystemd, "00", "00", "09", "*", "*", "1-5", "*"}},
+		{"Full", "Sat,Sun 2030-*-01 10:00:30", Source{DialectSystemd, "30", "00", "10", "01", "*", "6,0", "2030"}},
+		{"Sunday_Range", "Fri..Sun", Source{DialectSystemd, "00", "00", "00", "*", "*", "5-7", "*"}},
+		{"Sunday_Only_Range", "Sun..Sun", Source{DialectSystemd, "00", "00", "00", "*", "*", "7-7", "*"}},
+		{"Full_Names", "monday,Friday", Source{DialectSystemd, "00", "00", "00", "*", "*", "1,5", "*"}},
+		{"Repetition", "*:0/15", Source{DialectSystemd, "00", "0-59/15", "*", "*", "*", "*", "*"}},
+		{"Month_Day", "02-14 *:00", Source{DialectSystemd, "00", "00", "*", "14", "02", "*", "*"}},
+		{"Last_Day", "*-*~01", Source{DialectSystemd, "00", "00", "00", "L", "*", "*", "*"}},
+		{"Last_Days", "Mon *-05~07/3", Source{DialectSystemd, "00", "00", "00", "L-6,L-3,L", "05", "1", "*"}},
+		{"Shorthand", "quarterly", Source{DialectSystemd, "00", "00", "00", "01", "01,04,07,10", "*", "*"}},
+		{"Weekly", "weekly", Source{DialectSystemd, "00", "00", "00", "*", "*", "1", "*"}},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.spec, systemd)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, c.Source)
+			assert.Equal(t, tc.spec, c.Original)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Run times
+	t.Run("Runs", func(t *testing.T) {
+		c, err := ParseWithOptions("Mon *-*-01..07 09:30 UTC", systemd)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "UTC", c.Location.String())
+		assert.Equal(t, "Mon 2026-11-02 09:30:00 UTC", c.Next(time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)).Format("Mon 2006-01-02 15:04:05 MST"))
+		assert.Equal(t, "Mon 2026-12-07 09:30:00 UTC", c.Next(time.Date(2026, time.November, 3, 0, 0, 0, 0, time.UTC)).Format("Mon 2006-01-02 15:04:05 MST"))
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Converting back gives the same spec
+	for _, spec := range []string{"Mon..Fri *-*-* 09:00:00", "*-*~07/1 12:00:00", "Tue,Thu 2030-02..04-10,20 *:00/20:15 Europe/London"} {
+		t.Run("Round_Trip_"+spec, func(t *testing.T) {
+			c, err := ParseWithOptions(spec, systemd)
+			assert.Nil(t, err)
+
+			res, err := c.OnCalendar()
+			assert.Nil(t, err)
+			assert.Equal(t, []string{spec}, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid test cases
+	invalidTestCases := []struct {
+		name string
+		spec string
+		err  *ParseError
+	}{
+		{"Empty", "", nil},
+		{"Hour", "Mon *-*-* 25:00", &ParseError{"Mon *-*-* 25:00", "hour", 10, "25", "use 00-23, a range (ex 01..05) or a repetition (ex 00/15), separated by commas", errInvalid}},
+		{"Weekday", "Fri..Mon 10:00", &ParseError{"Fri..Mon 10:00", "weekday", 0, "Fri..Mon", "use Mon-Sun or a range (ex Mon..Fri), separated by commas", errInvalid}},
+		{"Weekday_Sunday_First", "Sun..Mon 10:00", &ParseError{"Sun..Mon 10:00", "weekday", 0, "Sun..Mon", "use Mon-Sun or a range (ex Mon..Fri), separated by commas", errInvalid}},
+		{"Last_Day", "*-*~32", &ParseError{"*-*~32", "day", 4, "32", "use days back from the last of the month, from 01 (ex ~01 or ~07/1)", errInvalid}},
+		{"Date", "1-2-3-4", &ParseError{"1-2-3-4", "date", 0, "1-2-3-4", "use year-month-day or month-day (ex *-*-01 or 01-15)", errInvalid}},
+		{"Time", "1:2:3:4", &ParseError{"1:2:3:4", "time", 0, "1:2:3:4", "use hour:minute or hour:minute:second (ex 09:00)", errInvalid}},
+		{"Fraction", "12:00:00.5", &ParseError{"12:00:00.5", "second", 6, "00.5", "cron runs on whole seconds", nil}},
+		{"Extra", "Mon 09:00 09:00 UTC", &ParseError{"Mon 09:00 09:00 UTC", "systemd", 10, "09:00", systemdFormat, errInvalid}},
+	}
+
+	for _, tc := range invalidTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseWithOptions(tc.spec, systemd)
+			assert.NotNil(t, err)
+			if tc.err == nil {
+				return
+			}
+
+			pe, ok := err.(*ParseError)
+			assert.True(t, ok)
+			if tc.err.Err == nil {
+				tc.err.Err = pe.Err
+			}
+			assert.Equal(t, tc.err, pe)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Unknown time zone
+	t.Run("Time_Zone", func(t *testing.T) {
+		_, err := ParseWithOptions("*-*-* 09:00 Mars/Olympus", systemd)
+		assert.EqualError(t, err, "parsing error - time zone - invalid - Mars/Olympus")
+	})
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Systemd_Expression(t *testing.T) {
+	systemd := ParseOptions{Dialect: DialectSystemd}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	validTestCases := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{"Weekdays", "Mon..Fri 09:00", "0 9 * * MON-FRI"},
+		{"Repetition", "*:0/15", "*/15 * * * *"},
+		{"Offset_Repetition", "*:5/15", "5-59/15 * * * *"},
+		{"Seconds", "*-*-* 12:00:30 Europe/London", "CRON_TZ=Europe/London 30 0 12 * * *"},
+		{"Year", "2030-01-01", "0 0 0 1 1 ? 2030"},
+		{"Year_Weekday", "Mon 2030-*-* 12:00", "0 0 12 ? * MON 2030"},
+		{"Last_Day", "*-*~01", "0 0 L * *"},
+		{"First_Monday", "Mon *-*-01..07", "0 0 * * 1#1"},
+		{"Fifth_Friday", "Fri *-*-29..31", "0 0 * * 5#5"},
+		{"Last_Thursday", "Thu *-*~07/1", "0 0 * * 4L"},
+		{"Last_Thursday_Year", "Thu 2030-*~07/1", "0 0 0 ? * 5L 2030"},
+		{"Step_Weekday", "Sun,Tue,Thu,Sat 00:00", "0 0 * * */2"},
+		{"Step_Day", "*-*-01/2", "0 0 */2 * *"},
+		{"Step_Day_Weekday", "Mon *-*-01/2", "0 0 */2 * MON"},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseWithOptions(tc.spec, systemd)
+			assert.Nil(t, err)
+
+			res, err := c.Expression()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Cron expressions
+	t.Run("Cron", func(t *testing.T) {
+		for exp, expected := range map[string]string{
+			"@reboot /cmd":            "@reboot",
+			"@every 90s /cmd":         "@every 1m30s",
+			"0 0 1,15 * 5 /cmd":       "0 0 1,15 * FRI",
+			"0 0 * * */2 /cmd":        "0 0 * * */2",
+			"0 0 1-31/2 * 1-5/2 /cmd": "0 0 1-31/2 * MON-SAT/2",
+			"0 0 1-31 * MON /cmd":     "0 0 * * *",
+		} {
+			c, err := Parse(exp)
+			assert.Nil(t, err)
+
+			res, err := c.Expression()
+			assert.Nil(t, err)
+			assert.Equal(t, expected, res)
+		}
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Quartz needs ? in one of the day fields
+	t.Run("Quartz_Both_Days", func(t *testing.T) {
+		c, err := ParseWithOptions("Mon 2030-*-01/2", systemd)
+		assert.Nil(t, err)
+
+		_, err = c.Expression()
+		assert.EqualError(t, err, "cron - days limited by both day of month and day of week have no Quartz equivalent, as one of them must be ?")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Days that must match both fields, which aren't a week of the month
+	for _, spec := range []string{"Mon *-*-01,15", "Mon,Tue *-*-01..07", "Mon *-*-02..08", "Mon *-*~03"} {
+		t.Run("Both_Days_"+spec, func(t *testing.T) {
+			c, err := ParseWithOptions(spec, systemd)
+			assert.Nil(t, err)
+
+			_, err = c.Expression()
+			assert.EqualError(t, err, "cron - days matching both day of month and day of week have no equivalent, as cron runs on days matching either")
+		})
+	}
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Systemd_Lists(t *testing.T) {
+	assert.Equal(t, "*", systemdList(defaultHourSlice, defaultHourSlice, "%02d"))
+	assert.Equal(t, "05/20", systemdList(IntSlice{5, 25, 45}, defaultMinuteSlice, "%02d"))
+	assert.Equal(t, "00,20,40,50", systemdList(IntSlice{0, 20, 40, 50}, defaultMinuteSlice, "%02d"))
+	assert.Equal(t, "01,02,04..06", systemdList(IntSlice{1, 2, 4, 5, 6}, defaultDomSlice, "%02d"))
+
+	assert.Equal(t, "Mon..Wed,Fri..Sun", systemdWeekdays(IntSlice{0, 1, 2, 3, 5, 6}))
+	assert.Equal(t, "01,03", systemdLastList(IntSlice{1, 3}))
+	assert.Equal(t, "07/3", systemdLastList(IntSlice{1, 4, 7}))
+
+	assert.Equal(t, "*/15", cronList(IntSlice{0, 15, 30, 45}, defaultMinuteSlice, nil))
+	assert.Equal(t, "1-5,7", cronList(IntSlice{1, 2, 3, 4, 5, 7}, defaultDomSlice, nil))
+	assert.Equal(t, "SUN,TUE-THU", cronList(IntSlice{0, 2, 3, 4}, defaultDowSlice, systemdDays))
+}
@@ -2,13 +2,16 @@ module visualcron
 
 go 1.18
 
+require (
+	github.com/gookit/goutil v0.4.6
+	github.com/stretchr/testify v1.7.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/gookit/goutil v0.4.6 // indirect
 	github.com/hashicorp/go-version v1.0.0 // indirect
 	github.com/mitchellh/gox v1.0.1 // indirect
 	github.com/mitchellh/iochan v1.0.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.7.1 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
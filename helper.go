@@ -2,24 +2,27 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
 // ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-// UniqueIntSlice removes duplicates from a int slice
-func UniqueIntSlice(in IntSlice) IntSlice {
-	keys := make(map[int]bool)
-	list := IntSlice{}
+// stdout is where output for other tools (ex JSON) is written, while
+// the tables, errors and warnings for people are logged
+var stdout io.Writer = os.Stdout
 
-	for _, entry := range in {
-		if _, value := keys[entry]; !value {
-			keys[entry] = true
-			list = append(list, entry)
-		}
+// printOutput writes output for other tools to stdout, ending with a
+// newline as log.Print does
+func printOutput(out string) {
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
 	}
-	return list
+	fmt.Fprint(stdout, out)
 }
 
 // ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
@@ -27,14 +30,85 @@ func UniqueIntSlice(in IntSlice) IntSlice {
 func CaptureOutput(f func()) string {
 	setLogFlags()
 
-	// Create a byte buffer a redirect log
+	// Create a byte buffer a redirect log and stdout
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
+	stdout = &buf
 
 	f()
 
 	// Reset logging
 	log.SetOutput(os.Stdout)
+	stdout = os.Stdout
 
 	return buf.String()
 }
+
+// CaptureStreams returns what f writes to stdout and what it logs,
+// separately
+func CaptureStreams(f func()) (string, string) {
+	setLogFlags()
+
+	var out, logged bytes.Buffer
+	log.SetOutput(&logged)
+	stdout = &out
+
+	f()
+
+	log.SetOutput(os.Stdout)
+	stdout = os.Stdout
+
+	return out.String(), logged.String()
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// timeInputLayouts are the layouts accepted by ParseTime. Layouts
+// without a zone are read in local time
+var timeInputLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a time given on the command line. An empty value
+// returns the fallback
+func ParseTime(value string, fallback time.Time) (time.Time, error) {
+	return ParseTimeIn(value, fallback, nil)
+}
+
+// ParseTimeIn parses a time given on the command line, reading
+// layouts without a zone in loc and returning the time in loc. A nil
+// loc is local time
+func ParseTimeIn(value string, fallback time.Time, loc *time.Location) (time.Time, error) {
+	in := loc
+	if in == nil {
+		in = time.Local
+	}
+
+	t := fallback
+	if value != "" {
+		var err error
+		if t, err = parseTimeLayouts(value, in); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if loc != nil && !t.IsZero() {
+		t = t.In(loc)
+	}
+
+	return t, nil
+}
+
+// parseTimeLayouts tries each of the timeInputLayouts in turn
+func parseTimeLayouts(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range timeInputLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("time - invalid - %s", value)
+}
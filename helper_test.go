@@ -3,16 +3,12 @@ package main
 import (
 	"log"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
-)
-
-func Test_Helper_UniqueIntSlice(t *testing.T) {
-	res := UniqueIntSlice(IntSlice{1, 1, 2, 3, 3, 4, 5, 6})
-	expected := IntSlice{1, 2, 3, 4, 5, 6}
 
-	assert.ElementsMatch(t, res, expected)
-}
+	"visualcron/cron"
+)
 
 func Test_Helper_CaptureLogs(t *testing.T) {
 	res := CaptureOutput(func() {
@@ -21,3 +17,64 @@ func Test_Helper_CaptureLogs(t *testing.T) {
 
 	assert.Equal(t, res, "test message\n")
 }
+
+func Test_Helper_CaptureStreams(t *testing.T) {
+	out, logged := CaptureStreams(func() {
+		printOutput("{}")
+		log.Print("warning - test message")
+	})
+
+	assert.Equal(t, "{}\n", out)
+	assert.Equal(t, "warning - test message\n", logged)
+}
+
+func Test_Helper_ParseTime(t *testing.T) {
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Valid test cases
+	fallback := time.Date(2022, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	validTestCases := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{"Empty", "", fallback},
+		{"RFC3339", "2022-06-14T10:30:00Z", time.Date(2022, 6, 14, 10, 30, 0, 0, time.UTC)},
+		{"Minute", "2022-06-14 10:30", time.Date(2022, 6, 14, 10, 30, 0, 0, time.Local)},
+		{"Date", "2022-06-14", time.Date(2022, 6, 14, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tc := range validTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ParseTime(tc.input, fallback)
+			assert.Nil(t, err)
+			assert.True(t, tc.expected.Equal(res))
+		})
+	}
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// Invalid
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseTime("14/06/2022", fallback)
+		assert.EqualError(t, err, "time - invalid - 14/06/2022")
+	})
+
+	// ~~~~~~~~~~~~~~~~~~~~~~~~~~~
+	// In a location
+	t.Run("Location", func(t *testing.T) {
+		loc, _ := cron.ParseLocation("Europe/Paris")
+
+		res, err := ParseTimeIn("2022-06-14 10:30", fallback, loc)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2022, 6, 14, 10, 30, 0, 0, loc), res)
+
+		// Times with a zone are moved into the location
+		res, err = ParseTimeIn("2022-06-14T10:30:00Z", fallback, loc)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2022, 6, 14, 12, 30, 0, 0, loc), res)
+
+		res, err = ParseTimeIn("", fallback, loc)
+		assert.Nil(t, err)
+		assert.Equal(t, fallback.In(loc), res)
+	})
+}
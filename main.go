@@ -10,23 +10,46 @@ import (
 func main() {
 	setLogFlags()
 
-	args := os.Args[1:]
+	os.Exit(run(os.Args[1:]))
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
+// run works out which command to run and returns the exit code
+//
+// When the first arg is not a known command the args are treated as
+// an expression, and its flags, to print as a table
+func run(args []string) int {
 	// Validate the args
 	if !argsValidation(args) {
 		log.Print("error - invalid input")
-		os.Exit(1)
+		return 1
 	}
 
-	// Parse the expression
-	cron, err := ParseExpression(args[0])
-	if err != nil {
-		log.Printf("error - %s", err.Error())
-		os.Exit(1)
+	switch args[0] {
+	case "next":
+		return runNext(args[1:])
+	case "prev":
+		return runPrev(args[1:])
+	case "between":
+		return runBetween(args[1:])
+	case "explain":
+		return runExplain(args[1:])
+	case "lint":
+		return runLint(args[1:])
+	case "overlap":
+		return runOverlap(args[1:])
+	case "export":
+		return runExport(args[1:])
+	case "convert":
+		return runConvert(args[1:])
+	case "k8s":
+		return runK8s(args[1:])
+	case "ci":
+		return runCI(args[1:])
 	}
 
-	// Print as table
-	cron.PrintTable()
+	return runTable(args)
 }
 
 // ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
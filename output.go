@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Format is the format a parsed expression is output in
+type Format string
+
+const (
+	// FormatTable is the tabwriter table from Cron.Table
+	FormatTable Format = "table"
+	// FormatJSON is indented JSON
+	FormatJSON Format = "json"
+	// FormatYAML is YAML, with the value lists on one line
+	FormatYAML Format = "yaml"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseFormat returns the Format with the given name
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatTable, FormatJSON, FormatYAML:
+		return format, nil
+	}
+
+	return FormatTable, fmt.Errorf("output - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// View is how table output shows a parsed expression
+type View string
+
+const (
+	// ViewTable lists the values of each field
+	ViewTable View = "table"
+	// ViewCalendar is a month grid with the days it fires marked, from
+	// Cron.Calendar
+	ViewCalendar View = "calendar"
+	// ViewHeatmap is a grid of when it fires within a day, from
+	// cron.Heatmap, with every expression overlaid
+	ViewHeatmap View = "heatmap"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// ParseView returns the View with the given name
+func ParseView(name string) (View, error) {
+	switch view := View(strings.ToLower(name)); view {
+	case ViewTable, ViewCalendar, ViewHeatmap:
+		return view, nil
+	}
+
+	return ViewTable, fmt.Errorf("view - unknown - %s", name)
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Marshal returns v as JSON or YAML
+func Marshal(v interface{}, format Format) (string, error) {
+	switch format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("output - %s", err)
+		}
+		return string(b) + "\n", nil
+	case FormatYAML:
+		var sb strings.Builder
+		enc := yaml.NewEncoder(&sb)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return "", fmt.Errorf("output - %s", err)
+		}
+		return sb.String(), nil
+	}
+
+	return "", fmt.Errorf("output - unknown - %s", format)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"visualcron/cron"
+)
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Output_ParseFormat(t *testing.T) {
+	format, err := ParseFormat("JSON")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ParseFormat("yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatYAML, format)
+
+	_, err = ParseFormat("xml")
+	assert.EqualError(t, err, "output - unknown - xml")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Output_ParseView(t *testing.T) {
+	view, err := ParseView("Calendar")
+	assert.Nil(t, err)
+	assert.Equal(t, ViewCalendar, view)
+
+	view, err = ParseView("table")
+	assert.Nil(t, err)
+	assert.Equal(t, ViewTable, view)
+
+	_, err = ParseView("grid")
+	assert.EqualError(t, err, "view - unknown - grid")
+}
+
+// ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+func Test_Output_Cron(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      string
+		format   Format
+		expected string
+	}{
+		{"JSON", "*/30 0 1,15 1 1-5 /usr/bin/find", FormatJSON, `{
+  "original": "*/30 0 1,15 1 1-5 /usr/bin/find",
+  "kind": "time",
+  "minute": [
+    0,
+    30
+  ],
+  "hour": [
+    0
+  ],
+  "day_of_month": [
+    1,
+    15
+  ],
+  "month": [
+    1
+  ],
+  "day_of_week": [
+    1,
+    2,
+    3,
+    4,
+    5
+  ],
+  "day_match": "or",
+  "command": "/usr/bin/find"
+}
+`},
+		{"YAML", "*/30 0 1,15 1 1-5 /usr/bin/find", FormatYAML, `original: '*/30 0 1,15 1 1-5 /usr/bin/find'
+kind: time
+minute: [0, 30]
+hour: [0]
+day_of_month: [1, 15]
+month: [1]
+day_of_week: [1, 2, 3, 4, 5]
+day_match: or
+command: /usr/bin/find
+`},
+		{"YAML_Rules", "0 0 12 L 1 ? 2030", FormatYAML, `original: 0 0 12 L 1 ? 2030
+kind: time
+second: [0]
+minute: [0]
+hour: [12]
+day_of_month_rules: [L]
+month: [1]
+day_of_week: [0, 1, 2, 3, 4, 5, 6]
+year: [2030]
+command: ""
+`},
+		{"JSON_Every", "@every 1h30m /usr/bin/sync", FormatJSON, `{
+  "original": "@every 1h30m /usr/bin/sync",
+  "kind": "interval",
+  "interval": "1h30m0s",
+  "command": "/usr/bin/sync"
+}
+`},
+		{"YAML_Time_Zone", "TZ=Europe/London 0 9 1 * * /usr/bin/backup", FormatYAML, `original: TZ=Europe/London 0 9 1 * * /usr/bin/backup
+kind: time
+minute: [0]
+hour: [9]
+day_of_month: [1]
+month: [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12]
+day_of_week: [0, 1, 2, 3, 4, 5, 6]
+time_zone: Europe/London
+command: /usr/bin/backup
+`},
+		{"YAML_Reboot", "@reboot /usr/bin/start", FormatYAML, `original: '@reboot /usr/bin/start'
+kind: reboot
+command: /usr/bin/start
+`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := cron.Parse(tc.exp)
+			assert.Nil(t, err)
+
+			out, err := Marshal(c, tc.format)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+
+	// Unknown format
+	_, err := Marshal(cron.Cron{}, FormatTable)
+	assert.EqualError(t, err, "output - unknown - table")
+}